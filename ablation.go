@@ -0,0 +1,135 @@
+package simulator
+
+import (
+	"math"
+	"sort"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+)
+
+/* Win rate of the analyzed team when one ability of one card (or one ruleset) is removed */
+type AblationResult struct {
+	Team     TeamNumber // team of the card that lost the ability, TEAM_NUM_UNKNOWN for rulesets
+	Position int        // position of the monster, SUMMONER_POSITION for the summoner
+	CardName string
+	Ability  Ability
+	Ruleset  Ruleset
+	Winrate  WinrateEstimate
+	// Winrate - baseline win rate and its 95% confidence bounds
+	Delta      float64
+	DeltaLower float64
+	DeltaUpper float64
+}
+
+/* Tells if the win rate changed for sure (the confidence bounds of the delta don't include 0) */
+func (r AblationResult) IsSignificant() bool {
+	return r.DeltaLower > 0 || r.DeltaUpper < 0
+}
+
+type AblationReport struct {
+	Team     TeamNumber
+	Baseline WinrateEstimate
+	Results  []AblationResult // sorted by the size of the delta, biggest first
+}
+
+/*
+Simulates the battle with one ability of one card removed at a time (both teams' cards),
+then with one ruleset removed at a time, and reports the win rate of the team against the baseline.
+Ties count as losses. Returns a *CardDecodeError if a card of the battle can't be set up.
+*/
+func AnalyzeAblation(cardDetailMap CardDetailMap, battleDetails BattleDetails, rulesets []Ruleset, team TeamNumber, iterations int) (AblationReport, error) {
+	game, err := CreateGame(cardDetailMap, battleDetails, rulesets, false)
+	if err != nil {
+		return AblationReport{}, err
+	}
+	createGameWithRulesets := func(rs []Ruleset) func() *Game {
+		return func() *Game {
+			// the cards were set up without errors above
+			game, _ := CreateGame(cardDetailMap, battleDetails, rs, false)
+			return &game
+		}
+	}
+	report := AblationReport{
+		Team:     team,
+		Baseline: EstimateWinrate(createGameWithRulesets(rulesets), team, iterations),
+	}
+
+	// abilities of the cards
+	for _, teamNumber := range []TeamNumber{TEAM_NUM_ONE, TEAM_NUM_TWO} {
+		gameTeam := game.GetTeam(teamNumber)
+		summoner := gameTeam.GetSummoner()
+		for _, ability := range getUniqueAbilities(summoner.Abilities) {
+			createGame := withPreGameHook(createGameWithRulesets(rulesets), removeCardAbilityHook(teamNumber, SUMMONER_POSITION, ability))
+			result := AblationResult{Team: teamNumber, Position: SUMMONER_POSITION, CardName: summoner.GetName(), Ability: ability}
+			report.addResult(result, EstimateWinrate(createGame, team, iterations))
+		}
+		for position, monster := range gameTeam.GetMonstersList() {
+			for _, ability := range getUniqueAbilities(monster.Abilities) {
+				createGame := withPreGameHook(createGameWithRulesets(rulesets), removeCardAbilityHook(teamNumber, position, ability))
+				result := AblationResult{Team: teamNumber, Position: position, CardName: monster.GetName(), Ability: ability}
+				report.addResult(result, EstimateWinrate(createGame, team, iterations))
+			}
+		}
+	}
+
+	// rulesets
+	for i, ruleset := range rulesets {
+		if ruleset == RULESET_STANDARD {
+			continue
+		}
+		remainingRulesets := make([]Ruleset, 0)
+		remainingRulesets = append(remainingRulesets, rulesets[:i]...)
+		remainingRulesets = append(remainingRulesets, rulesets[i+1:]...)
+		result := AblationResult{Team: TEAM_NUM_UNKNOWN, Ruleset: ruleset}
+		report.addResult(result, EstimateWinrate(createGameWithRulesets(remainingRulesets), team, iterations))
+	}
+
+	sort.SliceStable(report.Results, func(i, j int) bool {
+		return math.Abs(report.Results[i].Delta) > math.Abs(report.Results[j].Delta)
+	})
+	return report, nil
+}
+
+func (r *AblationReport) addResult(result AblationResult, winrate WinrateEstimate) {
+	result.Winrate = winrate
+	result.Delta, result.DeltaLower, result.DeltaUpper = winrate.GetDeltaBounds(r.Baseline)
+	r.Results = append(r.Results, result)
+}
+
+func withPreGameHook(createGame func() *Game, hook func(g *Game)) func() *Game {
+	return func() *Game {
+		game := createGame()
+		game.AddPreGameHook(hook)
+		return game
+	}
+}
+
+/* Removes every instance of the ability from the card. Cards are rebuilt when a game starts, so this has to run as a pre game hook. */
+func removeCardAbilityHook(team TeamNumber, position int, ability Ability) func(g *Game) {
+	return func(g *Game) {
+		gameTeam := g.GetTeam(team)
+		if position == SUMMONER_POSITION {
+			for gameTeam.GetSummoner().HasAbility(ability) {
+				gameTeam.GetSummoner().RemoveAbility(ability)
+			}
+			return
+		}
+		monster := gameTeam.GetMonstersList()[position]
+		for monster.HasAbility(ability) {
+			monster.RemoveAbility(ability)
+		}
+	}
+}
+
+func getUniqueAbilities(abilities []Ability) []Ability {
+	uniqueAbilities := make([]Ability, 0)
+	seen := make(map[Ability]bool)
+	for _, ability := range abilities {
+		if ability == "" || seen[ability] {
+			continue
+		}
+		seen[ability] = true
+		uniqueAbilities = append(uniqueAbilities, ability)
+	}
+	return uniqueAbilities
+}
@@ -0,0 +1,172 @@
+package simulator
+
+import (
+	"sort"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+)
+
+/* Averages and distributions of MonsterBattleStats of one monster over many games */
+type MonsterAnalytics struct {
+	Team         TeamNumber
+	Position     int
+	Name         string
+	CardDetailID int
+
+	AverageDamageDealt        map[CardAttackType]float64
+	AverageAbilityDamageDealt float64
+	AverageArmorDamageTaken   float64
+	AverageHealthDamageTaken  float64
+	AverageKills              float64
+	AverageHealingGiven       float64
+	AverageRepairGiven        float64
+	AverageTurnsTaken         float64
+	AverageTurnsStunned       float64
+	AverageDodges             float64
+	SurvivalRate              float64
+
+	// number of games per value
+	TotalDamageDistribution map[int]int
+	KillsDistribution       map[int]int
+	DeathRoundDistribution  map[int]int // 0 means the monster survived
+}
+
+/* Averages of SummonerBattleStats of one summoner over many games */
+type SummonerAnalytics struct {
+	Team                TeamNumber
+	Name                string
+	CardDetailID        int
+	AverageHealingGiven float64
+	AverageRepairGiven  float64
+	AverageCleanses     float64
+}
+
+type BattleAnalytics struct {
+	Games        int
+	Team1WinRate float64
+	Team2WinRate float64
+	DrawRate     float64
+	Monsters     []MonsterAnalytics
+	Summoners    []SummonerAnalytics
+}
+
+/* Fetches the battle and runs SimulateBattleAnalytics on it */
+func GetBattleAnalytics(battleId string, iterations int) (BattleAnalytics, error) {
+	cardDetailMap, _ := GetAllCardDetail()
+	historicBattle := GetHistoricBattle(battleId)
+	battleDetails := ParseBattleDetails(historicBattle)
+	rulesets := ParseRulesets(historicBattle.Ruleset)
+	return SimulateBattleAnalytics(cardDetailMap, battleDetails, rulesets, iterations)
+}
+
+/* Plays the battle iterations times and aggregates the stats of every monster and summoner. Returns a *CardDecodeError if a card can't be set up */
+func SimulateBattleAnalytics(cardDetailMap CardDetailMap, battleDetails BattleDetails, rulesets []Ruleset, iterations int) (BattleAnalytics, error) {
+	createGame, err := newGameFactory(func() (Game, error) {
+		return CreateGame(cardDetailMap, battleDetails, rulesets, false)
+	})
+	if err != nil {
+		return BattleAnalytics{}, err
+	}
+	return RunBattleAnalytics(createGame, iterations), nil
+}
+
+/* Plays games created by createGame iterations times and aggregates the stats of every monster and summoner */
+func RunBattleAnalytics(createGame func() *Game, iterations int) BattleAnalytics {
+	analytics := BattleAnalytics{Games: iterations}
+	if iterations <= 0 {
+		return analytics
+	}
+
+	monsterStatsMap := make(map[string][]MonsterBattleStats)
+	summonerStatsMap := make(map[TeamNumber][]SummonerBattleStats)
+	winCounts := make(map[TeamNumber]int)
+	for i := 0; i < iterations; i++ {
+		game := createGame()
+		collector := NewBattleStatsCollector()
+		game.AddEventListener(collector)
+		collector.AddGameCards(game)
+		game.PlayGame()
+		winCounts[game.GetWinner()] += 1
+
+		for _, stats := range collector.GetMonsterStats() {
+			key := GetMonsterStatsKey(stats.Team, stats.Position)
+			monsterStatsMap[key] = append(monsterStatsMap[key], stats)
+		}
+		for _, stats := range collector.GetSummonerStats() {
+			summonerStatsMap[stats.Team] = append(summonerStatsMap[stats.Team], stats)
+		}
+	}
+
+	games := float64(iterations)
+	analytics.Team1WinRate = float64(winCounts[TEAM_NUM_ONE]) / games
+	analytics.Team2WinRate = float64(winCounts[TEAM_NUM_TWO]) / games
+	analytics.DrawRate = float64(winCounts[TEAM_NUM_TIE]) / games
+
+	for _, statsList := range monsterStatsMap {
+		analytics.Monsters = append(analytics.Monsters, aggregateMonsterStats(statsList, games))
+	}
+	sort.Slice(analytics.Monsters, func(i, j int) bool {
+		if analytics.Monsters[i].Team != analytics.Monsters[j].Team {
+			return analytics.Monsters[i].Team < analytics.Monsters[j].Team
+		}
+		return analytics.Monsters[i].Position < analytics.Monsters[j].Position
+	})
+
+	for _, statsList := range summonerStatsMap {
+		analytics.Summoners = append(analytics.Summoners, aggregateSummonerStats(statsList, games))
+	}
+	sort.Slice(analytics.Summoners, func(i, j int) bool {
+		return analytics.Summoners[i].Team < analytics.Summoners[j].Team
+	})
+	return analytics
+}
+
+func aggregateMonsterStats(statsList []MonsterBattleStats, games float64) MonsterAnalytics {
+	first := statsList[0]
+	ma := MonsterAnalytics{
+		Team:                    first.Team,
+		Position:                first.Position,
+		Name:                    first.Name,
+		CardDetailID:            first.CardDetailID,
+		AverageDamageDealt:      make(map[CardAttackType]float64),
+		TotalDamageDistribution: make(map[int]int),
+		KillsDistribution:       make(map[int]int),
+		DeathRoundDistribution:  make(map[int]int),
+	}
+
+	survived := 0
+	for _, stats := range statsList {
+		for attackType, damage := range stats.DamageDealt {
+			ma.AverageDamageDealt[attackType] += float64(damage) / games
+		}
+		ma.AverageAbilityDamageDealt += float64(stats.AbilityDamageDealt) / games
+		ma.AverageArmorDamageTaken += float64(stats.ArmorDamageTaken) / games
+		ma.AverageHealthDamageTaken += float64(stats.HealthDamageTaken) / games
+		ma.AverageKills += float64(stats.Kills) / games
+		ma.AverageHealingGiven += float64(stats.HealingGiven) / games
+		ma.AverageRepairGiven += float64(stats.RepairGiven) / games
+		ma.AverageTurnsTaken += float64(stats.TurnsTaken) / games
+		ma.AverageTurnsStunned += float64(stats.TurnsStunned) / games
+		ma.AverageDodges += float64(stats.Dodges) / games
+
+		ma.TotalDamageDistribution[stats.GetTotalDamageDealt()] += 1
+		ma.KillsDistribution[stats.Kills] += 1
+		ma.DeathRoundDistribution[stats.DeathRound] += 1
+		if stats.DeathRound == 0 {
+			survived += 1
+		}
+	}
+	ma.SurvivalRate = float64(survived) / games
+	return ma
+}
+
+func aggregateSummonerStats(statsList []SummonerBattleStats, games float64) SummonerAnalytics {
+	first := statsList[0]
+	sa := SummonerAnalytics{Team: first.Team, Name: first.Name, CardDetailID: first.CardDetailID}
+	for _, stats := range statsList {
+		sa.AverageHealingGiven += float64(stats.HealingGiven) / games
+		sa.AverageRepairGiven += float64(stats.RepairGiven) / games
+		sa.AverageCleanses += float64(stats.Cleanses) / games
+	}
+	return sa
+}
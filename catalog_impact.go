@@ -0,0 +1,123 @@
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+)
+
+/* A saved battle setup to re-simulate */
+type Matchup struct {
+	Name     string
+	Team1    BattleTeam
+	Team2    BattleTeam
+	Rulesets []Ruleset
+}
+
+/* Win rate of team 1 of a matchup before and after a catalog change */
+type MatchupImpact struct {
+	Matchup Matchup
+	Before  WinrateEstimate
+	After   WinrateEstimate
+	// After - Before win rate and its 95% confidence bounds
+	Delta      float64
+	DeltaLower float64
+	DeltaUpper float64
+	// the favored team changed: the 95% confidence bounds of the win rates are on opposite sides of 0.5
+	IsFlipped bool
+}
+
+type CatalogImpactReport struct {
+	Changes []CardDiff
+	// cards left out of the changes because a version of them can't be decoded
+	DecodeErrors []error
+	Matchups     []MatchupImpact // sorted by the size of the win rate change, biggest first
+	// matchups left out because a card of them is unknown or can't be set up with one of the catalogs
+	MatchupErrors []error
+}
+
+/* Matchups whose favored team changed */
+func (r CatalogImpactReport) GetFlippedMatchups() []MatchupImpact {
+	flipped := make([]MatchupImpact, 0)
+	for _, m := range r.Matchups {
+		if m.IsFlipped {
+			flipped = append(flipped, m)
+		}
+	}
+	return flipped
+}
+
+/* The favored team changed between the estimates, beyond the noise of the sampling: one is surely above 0.5 and the other surely below */
+func IsWinrateFlipped(before, after WinrateEstimate) bool {
+	return (before.Lower > 0.5 && after.Upper < 0.5) || (before.Upper < 0.5 && after.Lower > 0.5)
+}
+
+/*
+Parses a card catalog snapshot (a saved cards/get_details payload).
+Malformed cards are left out and returned as errors, the error is returned when the file can't be parsed.
+*/
+func LoadCardCatalog(path string) (CardDetailMap, []error, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	var cardDetails []CardDetail
+	if err := json.Unmarshal(data, &cardDetails); err != nil {
+		return nil, nil, err
+	}
+
+	cardDetailMap, decodeErrors := DecodeCardDetails(cardDetails)
+	return cardDetailMap, decodeErrors, nil
+}
+
+/* Parses a JSON list of matchups */
+func LoadMatchups(path string) ([]Matchup, error) {
+	matchups := make([]Matchup, 0)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return matchups, err
+	}
+	err = json.Unmarshal(data, &matchups)
+	return matchups, err
+}
+
+/* Lists the cards that changed between the catalogs and re-simulates every matchup under both */
+func AnalyzeCatalogImpact(before, after CardDetailMap, matchups []Matchup, iterations int) CatalogImpactReport {
+	changes, decodeErrors := DiffCardCatalogs(before, after)
+	report := CatalogImpactReport{Changes: changes, DecodeErrors: decodeErrors, Matchups: make([]MatchupImpact, 0, len(matchups)), MatchupErrors: make([]error, 0)}
+	for _, matchup := range matchups {
+		battleDetails := BattleDetails{Team1: matchup.Team1, Team2: matchup.Team2}
+		createGame := func(cardDetailMap CardDetailMap) (func() *Game, error) {
+			return newGameFactory(func() (Game, error) {
+				return CreateGame(cardDetailMap, battleDetails, matchup.Rulesets, false)
+			})
+		}
+		createBeforeGame, err := createGame(before)
+		if err != nil {
+			report.MatchupErrors = append(report.MatchupErrors, fmt.Errorf("matchup %s before the change: %w", matchup.Name, err))
+			continue
+		}
+		createAfterGame, err := createGame(after)
+		if err != nil {
+			report.MatchupErrors = append(report.MatchupErrors, fmt.Errorf("matchup %s after the change: %w", matchup.Name, err))
+			continue
+		}
+
+		impact := MatchupImpact{
+			Matchup: matchup,
+			Before:  EstimateWinrate(createBeforeGame, TEAM_NUM_ONE, iterations),
+			After:   EstimateWinrate(createAfterGame, TEAM_NUM_ONE, iterations),
+		}
+		impact.Delta, impact.DeltaLower, impact.DeltaUpper = impact.After.GetDeltaBounds(impact.Before)
+		impact.IsFlipped = IsWinrateFlipped(impact.Before, impact.After)
+		report.Matchups = append(report.Matchups, impact)
+	}
+	sort.SliceStable(report.Matchups, func(i, j int) bool {
+		return math.Abs(report.Matchups[i].Delta) > math.Abs(report.Matchups[j].Delta)
+	})
+	return report
+}
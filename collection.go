@@ -0,0 +1,121 @@
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+)
+
+const COLLECTION_ENDPOINT = "cards/collection/"
+
+/* Card list of a player, in the shape of the collection endpoint */
+type PlayerCollection struct {
+	Player string           `json:"player"`
+	Cards  []CollectionCard `json:"cards"`
+}
+
+/* Best playable card of each card detail id in a collection */
+type CardPool map[int]CollectionCard
+
+func GetPlayerCollection(player string) PlayerCollection {
+	resp, err := http.Get(SPL_API_URL + COLLECTION_ENDPOINT + player)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer resp.Body.Close()
+
+	var collection PlayerCollection
+	err = json.NewDecoder(resp.Body).Decode(&collection)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return collection
+}
+
+/* Loads a collection saved from the collection endpoint */
+func LoadPlayerCollection(path string) (PlayerCollection, error) {
+	var collection PlayerCollection
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return collection, err
+	}
+	err = json.Unmarshal(data, &collection)
+	if err != nil {
+		return collection, fmt.Errorf("failed to parse collection %s: %w", path, err)
+	}
+	return collection, nil
+}
+
+/*
+Derives the level of every card from its xp and keeps the highest level card of each card detail id.
+Cards unknown to cardDetailMap are skipped.
+*/
+func GetCardPool(cardDetailMap CardDetailMap, collection PlayerCollection) CardPool {
+	pool := make(CardPool)
+	for _, card := range collection.Cards {
+		cardDetail, ok := cardDetailMap[card.CardDetailID]
+		if !ok {
+			continue
+		}
+		card.Level = GetCardLevelFromXP(cardDetail, card.XP, card.Edition, card.Gold)
+		if current, ok := pool[card.CardDetailID]; ok && current.Level >= card.Level {
+			continue
+		}
+		pool[card.CardDetailID] = card
+	}
+	return pool
+}
+
+func (p CardPool) HasCard(cardDetailID int) bool {
+	_, ok := p[cardDetailID]
+	return ok
+}
+
+/* Level of the card in the pool, 0 if the player doesn't own it */
+func (p CardPool) GetLevel(cardDetailID int) int {
+	return p[cardDetailID].Level
+}
+
+/* Cards of the pool sorted by card detail id */
+func (p CardPool) GetCards() []CollectionCard {
+	cards := make([]CollectionCard, 0, len(p))
+	for _, card := range p {
+		cards = append(cards, card)
+	}
+	sort.Slice(cards, func(i, j int) bool {
+		return cards[i].CardDetailID < cards[j].CardDetailID
+	})
+	return cards
+}
+
+/* Cards of the pool legal in the format */
+func (p CardPool) FilterByFormat(cardDetailMap CardDetailMap, format CardFormat) CardPool {
+	legalPool := make(CardPool)
+	for id, card := range p {
+		if cardDetail, ok := cardDetailMap[id]; ok && format.IsCardLegal(cardDetail) {
+			legalPool[id] = card
+		}
+	}
+	return legalPool
+}
+
+/* Cards of the pool that can be played in the match, at most at the level cap of the match */
+func (p CardPool) FilterByMatchSettings(cardDetailMap CardDetailMap, settings MatchSettings) CardPool {
+	allowedPool := make(CardPool)
+	for id, card := range p {
+		cardDetail, ok := cardDetailMap[id]
+		if !ok || !settings.IsCardAllowed(cardDetail, card) {
+			continue
+		}
+		if levelCap := settings.GetLevelCap(cardDetail.Rarity); levelCap > 0 && card.Level > levelCap {
+			card.Level = levelCap
+		}
+		allowedPool[id] = card
+	}
+	return allowedPool
+}
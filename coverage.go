@@ -0,0 +1,72 @@
+package simulator
+
+import (
+	"fmt"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+)
+
+/* What to do with a battle that has abilities or rulesets the engine doesn't implement */
+type CoveragePolicy int
+
+const (
+	// simulate anyway, the returned report tells the result is unreliable
+	COVERAGE_POLICY_FLAG CoveragePolicy = iota
+	// don't simulate, return an UnsupportedMechanicsError
+	COVERAGE_POLICY_REFUSE
+)
+
+/* Abilities (at the played levels) and rulesets of the battle the engine doesn't implement. Returns a *CardDecodeError if a card can't be set up */
+func CheckBattleCoverage(cardDetailMap CardDetailMap, battleDetails BattleDetails, rulesets []Ruleset) (CoverageReport, error) {
+	game, err := CreateGame(cardDetailMap, battleDetails, rulesets, false)
+	if err != nil {
+		return NewCoverageReport(), err
+	}
+	return game.CheckCoverage(), nil
+}
+
+/* Abilities and rulesets of the historic battles the engine doesn't implement. Battles with cards that can't be set up are left out and returned as errors */
+func CheckCorpusCoverage(cardDetailMap CardDetailMap, historicBattles []BattleHistory) (CoverageReport, []error) {
+	report := NewCoverageReport()
+	errs := make([]error, 0)
+	for _, historicBattle := range historicBattles {
+		battleDetails := ParseBattleDetails(historicBattle)
+		rulesets := ParseRulesets(historicBattle.Ruleset)
+		battleReport, err := CheckBattleCoverage(cardDetailMap, battleDetails, rulesets)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("battle %s: %w", historicBattle.BattleQueueId1, err))
+			continue
+		}
+		report.Merge(battleReport)
+	}
+	return report, errs
+}
+
+/* Same as CreateGame but checks the coverage of the battle first. Refuses to create the game with COVERAGE_POLICY_REFUSE when something is unsupported. */
+func CreateCheckedGame(cardDetailMap CardDetailMap, battleDetails BattleDetails, rulesets []Ruleset, shouldLog bool, policy CoveragePolicy) (Game, CoverageReport, error) {
+	game, err := CreateGame(cardDetailMap, battleDetails, rulesets, shouldLog)
+	if err != nil {
+		return Game{}, NewCoverageReport(), err
+	}
+	report := game.CheckCoverage()
+	if policy == COVERAGE_POLICY_REFUSE && !report.IsSupported() {
+		return Game{}, report, &UnsupportedMechanicsError{Report: report}
+	}
+	return game, report, nil
+}
+
+/* Same as SimulateBattle but checks the coverage of the battle. The result is unreliable when the report is not supported. */
+func SimulateCheckedBattle(battleId string, shouldLog bool, policy CoveragePolicy) ([]BattleLog, CoverageReport, error) {
+	cardDetailMap, _ := GetAllCardDetail()
+	historicBattle := GetHistoricBattle(battleId)
+
+	battleDetails := ParseBattleDetails(historicBattle)
+	rulesets := ParseRulesets(historicBattle.Ruleset)
+	game, report, err := CreateCheckedGame(cardDetailMap, battleDetails, rulesets, shouldLog, policy)
+	if err != nil {
+		return nil, report, err
+	}
+	game.PlayGame()
+	logs, err := game.GetBattleLogs()
+	return logs, report, err
+}
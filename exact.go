@@ -0,0 +1,27 @@
+package simulator
+
+import (
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+)
+
+/*
+Exact probability of each result of the battle. Battles with more than budget replayed games
+are estimated with fallbackIterations games instead, see ExactSolverResult.IsExact.
+A budget or fallbackIterations of 0 or less is the EXACT_SOLVER_DEFAULT_* value.
+Returns a *CardDecodeError if a card of the battle can't be set up.
+*/
+func SolveBattleExactly(cardDetailMap CardDetailMap, battleDetails BattleDetails, rulesets []Ruleset, budget int, fallbackIterations int) (ExactSolverResult, error) {
+	createGame, err := createBattleGame(cardDetailMap, battleDetails, rulesets)
+	if err != nil {
+		return ExactSolverResult{}, err
+	}
+	solver := NewExactSolver(createGame)
+	solver.Budget = budget
+	solver.FallbackIterations = fallbackIterations
+	return solver.Solve(), nil
+}
+
+/* Same as SolveBattleExactly with a saved battle */
+func SolveSavedBattleExactly(cardDetailMap CardDetailMap, historicBattle BattleHistory, budget int, fallbackIterations int) (ExactSolverResult, error) {
+	return SolveBattleExactly(cardDetailMap, ParseBattleDetails(historicBattle), ParseRulesets(historicBattle.Ruleset), budget, fallbackIterations)
+}
@@ -137,7 +137,8 @@ func RepairMonsterArmor(m *MonsterCard) int {
 	return newArmorAmount - previousArmor
 }
 
-func TankHealMonster(m *MonsterCard) int {
+/* Heals the monster by the multiplier of its max health (TANK_HEAL_MULTIPLIER by default) */
+func TankHealMonster(m *MonsterCard, multiplier float64) int {
 	if m == nil {
 		return 0
 	}
@@ -146,20 +147,21 @@ func TankHealMonster(m *MonsterCard) int {
 	}
 	previousHealth := m.Health
 	maxHealth := m.GetPostAbilityMaxHealth()
-	healAmount := int(math.Floor(float64(maxHealth) * TANK_HEAL_MULTIPLIER))
+	healAmount := int(math.Floor(float64(maxHealth) * multiplier))
 	healAmount = utils.GetBigger(healAmount, 2)
 	m.AddHealth(healAmount)
 	return m.Health - previousHealth
 }
 
-func TriageHealMonster(m *MonsterCard) int {
+/* Heals the monster by the multiplier of its max health (TRIAGE_HEAL_MULTIPLIER by default) */
+func TriageHealMonster(m *MonsterCard, multiplier float64) int {
 	if m == nil || m.HasDebuff(ABILITY_AFFLICTION) {
 		return 0
 	}
 
 	previousHealth := m.Health
 	maxHealth := m.GetPostAbilityMaxHealth()
-	healAmount := int(math.Floor(float64(maxHealth) * TRIAGE_HEAL_MULTIPLIER))
+	healAmount := int(math.Floor(float64(maxHealth) * multiplier))
 	healAmount = utils.GetBigger(healAmount, MINIMUM_TRIAGE_HEAL)
 	m.AddHealth(healAmount)
 	return m.Health - previousHealth
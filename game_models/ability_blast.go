@@ -0,0 +1,79 @@
+package game_models
+
+import "math"
+
+/*
+The attack of the owner also hits the monsters next to the target.
+Blast has a ton of edge cases... https://support.splinterlands.com/hc/en-us/articles/4414966685332-Abilities-Status-Effects
+*/
+type BlastHandler struct {
+	BaseAbilityHandler
+}
+
+func (BlastHandler) GetAbility() Ability {
+	return ABILITY_BLAST
+}
+
+func (h BlastHandler) OnHit(g *Game, owner *MonsterCard, hit *AbilityHitContext) {
+	if hit.IsAbsorbed && g.rulesBehavior.DivineShieldStopsBlast {
+		return
+	}
+	h.blast(g, owner, hit.PrevMonster, hit.AttackType, hit.DamageAmount)
+	h.blast(g, owner, hit.NextMonster, hit.AttackType, hit.DamageAmount)
+}
+
+func (BlastHandler) blast(g *Game, attacker, blastTarget *MonsterCard, attackType CardAttackType, damageAmount int) {
+	if !attacker.HasAbility(ABILITY_BLAST) || blastTarget == nil {
+		return
+	}
+
+	baseBlastDamage := int(math.Ceil(float64(damageAmount) * g.GetConfig().BlastMultiplier))
+	damageMultiplier := g.GetPostBlastDamageMultiplier(attacker, blastTarget)
+	blastDamage := baseBlastDamage * damageMultiplier
+
+	reductions := []DamageReduction{}
+
+	// Forcefield
+	if blastTarget.HasAbility(ABILITY_FORCEFIELD) && blastDamage >= g.GetConfig().ForcefieldMinDamage {
+		reductions = append(reductions, DamageReduction{Ability: ABILITY_FORCEFIELD, Before: blastDamage, After: 1})
+		blastDamage = 1
+	}
+
+	// Snare
+	if blastTarget.HasAbility(ABILITY_FLYING) && attacker.HasAbility(ABILITY_SNARE) && !blastTarget.HasDebuff(ABILITY_SNARE) {
+		g.AddMonsterDebuffToAMonster(attacker, blastTarget, ABILITY_SNARE, BATTLE_ACTION_SNARE)
+	}
+
+	// Reflection shield
+	if blastTarget.HasAbility(ABILITY_REFLECTION_SHIELD) {
+		reductions = append(reductions, DamageReduction{Ability: ABILITY_REFLECTION_SHIELD, Before: blastDamage, After: 0})
+		blastDamage = 0
+	}
+
+	// Magic blast damage
+	if attackType == ATTACK_TYPE_MAGIC {
+		battleDamage := HitMonsterWithMagic(g, blastTarget, blastDamage)
+		g.recordBlastCalculation(attacker, attackType, battleDamage, baseBlastDamage, damageMultiplier, reductions)
+		g.CreateAndAddBattleLog(BATTLE_ACTION_BLAST, attacker, blastTarget, battleDamage.DamageDone)
+		reflected := g.MaybeApplyMagicReflect(attacker, blastTarget, attackType, battleDamage.Attack)
+		if reflected.Calculation != nil {
+			g.addDamageSideEffect(battleDamage.Calculation, ABILITY_MAGIC_REFLECT, attacker, reflected.Calculation.GetTotalDamage())
+		}
+		if lifeLeechAmount := g.MaybeApplyLifeLeech(attacker, (blastDamage - battleDamage.Remainder)); lifeLeechAmount > 0 {
+			g.addDamageSideEffect(battleDamage.Calculation, ABILITY_LIFE_LEECH, attacker, lifeLeechAmount)
+		}
+	} else {
+		// melee or range attack
+		battleDamage := HitMonsterWithPhysical(g, blastTarget, blastDamage)
+		g.recordBlastCalculation(attacker, attackType, battleDamage, baseBlastDamage, damageMultiplier, reductions)
+		g.CreateAndAddBattleLog(BATTLE_ACTION_BLAST, attacker, blastTarget, battleDamage.DamageDone)
+		returned := g.MaybeApplyReturnFire(attacker, blastTarget, attackType, battleDamage.Attack)
+		if returned.Calculation != nil {
+			g.addDamageSideEffect(battleDamage.Calculation, ABILITY_RETURN_FIRE, attacker, returned.Calculation.GetTotalDamage())
+		}
+	}
+
+	// check dead monster
+	g.ProcessIfDead(blastTarget)
+	g.ProcessIfDead(attacker)
+}
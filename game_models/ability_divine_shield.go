@@ -0,0 +1,24 @@
+package game_models
+
+/* The first hit of the owner does no damage and removes the shield */
+type DivineShieldHandler struct {
+	BaseAbilityHandler
+}
+
+func (DivineShieldHandler) GetAbility() Ability {
+	return ABILITY_DIVINE_SHIELD
+}
+
+func (DivineShieldHandler) OnBeforeBeingHit(g *Game, owner *MonsterCard, hit *AbilityHitContext) bool {
+	if !owner.HasAbility(ABILITY_DIVINE_SHIELD) {
+		return false
+	}
+	owner.RemoveDivineShield()
+	if hit.Attacker != nil {
+		g.CreateAndAddBattleLog(BATTLE_ACTION_REMOVE_DIVINE_SHIELD, hit.Attacker, owner, 0)
+	} else {
+		// side effect damage (e.g. thorns, earthquake) has no attacker to log
+		g.CreateAndAddBattleLog(BATTLE_ACTION_REMOVE_DIVINE_SHIELD, owner, nil, 0)
+	}
+	return true
+}
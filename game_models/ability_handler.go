@@ -0,0 +1,326 @@
+package game_models
+
+import (
+	"fmt"
+	"sync"
+
+	utils "github.com/YukiUmetsu/go-spl-simulator/game_utils"
+)
+
+/* What happened in an attack. Passed to the on-attack, on-hit and on-being-hit hooks */
+type AbilityHitContext struct {
+	Attacker   *MonsterCard
+	Target     *MonsterCard
+	AttackType CardAttackType
+	// post ability attack of the attacker for the attack type, what reflections are based on
+	AttackDamage int
+	// nil calculation if the attack didn't land (yet)
+	Damage BattleDamage
+	// attack damage with the damage multipliers, before the reductions of the target
+	DamageAmount int
+	// alive neighbours of the target when the attack started
+	PrevMonster *MonsterCard
+	NextMonster *MonsterCard
+	// an ability of the target absorbed the attack (e.g. Divine Shield)
+	IsAbsorbed bool
+}
+
+/*
+Behavior of an ability. The game calls the hooks of every registered handler whose ability the card has.
+Embed BaseAbilityHandler to only implement the hooks the ability needs.
+  - OnPreGame: after the summoner and monster pre game buffs / debuffs
+  - OnPreTurn: at the start of the turn of the owner
+  - OnAttack: the owner attacks, before the attack lands
+  - OnBeforeBeingHit: the owner is about to take damage. Returning true absorbs it, the hit does no damage
+  - OnHit: the attack of the owner was resolved (landed or absorbed) and the dead monsters were processed
+  - OnBeingHit: the owner was hit. Returned damage (if any) is recorded as a side effect of the hit
+  - OnDeath: the owner died
+  - OnMonsterDeath: another monster died while the owner is alive
+  - OnPostRound: at the end of every round
+  - ModifyStat: changes the post ability stats of the owner
+
+Buffs and debuffs cast by other cards (e.g. Inspire, Slow, Halving) and the multipliers of the owner whose order
+matters (Last Stand, Enrage) are applied by the post ability stat getters of MonsterCard, before ModifyStat.
+*/
+type AbilityHandler interface {
+	GetAbility() Ability
+	OnPreGame(g *Game, owner *MonsterCard)
+	OnPreTurn(g *Game, owner *MonsterCard)
+	OnAttack(g *Game, owner *MonsterCard, hit *AbilityHitContext)
+	OnBeforeBeingHit(g *Game, owner *MonsterCard, hit *AbilityHitContext) bool
+	OnHit(g *Game, owner *MonsterCard, hit *AbilityHitContext)
+	OnBeingHit(g *Game, owner *MonsterCard, hit *AbilityHitContext) BattleDamage
+	OnDeath(g *Game, owner *MonsterCard)
+	OnMonsterDeath(g *Game, owner *MonsterCard, deadMonster *MonsterCard)
+	OnPostRound(g *Game, owner *MonsterCard)
+	ModifyStat(owner *MonsterCard, stat Stat, value int) int
+}
+
+/* No-op implementation of every hook of AbilityHandler */
+type BaseAbilityHandler struct{}
+
+func (BaseAbilityHandler) OnPreGame(g *Game, owner *MonsterCard)                         {}
+func (BaseAbilityHandler) OnPreTurn(g *Game, owner *MonsterCard)                         {}
+func (BaseAbilityHandler) OnAttack(g *Game, owner *MonsterCard, hit *AbilityHitContext)  {}
+func (BaseAbilityHandler) OnHit(g *Game, owner *MonsterCard, hit *AbilityHitContext)     {}
+func (BaseAbilityHandler) OnDeath(g *Game, owner *MonsterCard)                           {}
+func (BaseAbilityHandler) OnMonsterDeath(g *Game, owner *MonsterCard, dead *MonsterCard) {}
+func (BaseAbilityHandler) OnPostRound(g *Game, owner *MonsterCard)                       {}
+func (BaseAbilityHandler) ModifyStat(owner *MonsterCard, stat Stat, value int) int       { return value }
+func (BaseAbilityHandler) OnBeforeBeingHit(g *Game, owner *MonsterCard, hit *AbilityHitContext) bool {
+	return false
+}
+func (BaseAbilityHandler) OnBeingHit(g *Game, owner *MonsterCard, hit *AbilityHitContext) BattleDamage {
+	return BattleDamage{}
+}
+
+/*
+Handlers in registration order. Built in handlers are listed here so they always run first
+and in the order the engine used to apply them.
+*/
+func getBuiltInAbilityHandlers() []AbilityHandler {
+	return []AbilityHandler{
+		DivineShieldHandler{},
+		ThornsHandler{},
+		MagicReflectHandler{},
+		ReturnFireHandler{},
+		RetaliateHandler{},
+		RedemptionHandler{},
+		ScavengerHandler{},
+		BlastHandler{},
+	}
+}
+
+/* Games are played in parallel, so the registry is guarded by abilityHandlersMutex */
+var abilityHandlers = getBuiltInAbilityHandlers()
+var abilityHandlersMutex sync.RWMutex
+
+/* Registers the handler of an ability. Replaces the handler already registered for the ability, keeping its order. */
+func RegisterAbilityHandler(handler AbilityHandler) {
+	abilityHandlersMutex.Lock()
+	defer abilityHandlersMutex.Unlock()
+	for i, h := range abilityHandlers {
+		if h.GetAbility() == handler.GetAbility() {
+			abilityHandlers[i] = handler
+			return
+		}
+	}
+	abilityHandlers = append(abilityHandlers, handler)
+}
+
+/* Removes the handler of the ability */
+func UnregisterAbilityHandler(ability Ability) error {
+	abilityHandlersMutex.Lock()
+	defer abilityHandlersMutex.Unlock()
+	for i, h := range abilityHandlers {
+		if h.GetAbility() == ability {
+			abilityHandlers = append(abilityHandlers[:i:i], abilityHandlers[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("no handler registered for the ability %q", ability)
+}
+
+/* Removes the registered handlers and restores the built in ones */
+func ResetAbilityHandlers() {
+	abilityHandlersMutex.Lock()
+	defer abilityHandlersMutex.Unlock()
+	abilityHandlers = getBuiltInAbilityHandlers()
+}
+
+func GetAbilityHandler(ability Ability) AbilityHandler {
+	abilityHandlersMutex.RLock()
+	defer abilityHandlersMutex.RUnlock()
+	for _, h := range abilityHandlers {
+		if h.GetAbility() == ability {
+			return h
+		}
+	}
+	return nil
+}
+
+/* Tells if the ability has a registered handler */
+func HasAbilityHandler(ability Ability) bool {
+	return GetAbilityHandler(ability) != nil
+}
+
+/* Handlers of the abilities the monster has, in registration order */
+func getAbilityHandlersOf(m *MonsterCard) []AbilityHandler {
+	handlers := make([]AbilityHandler, 0)
+	if m == nil || len(m.Abilities) == 0 {
+		return handlers
+	}
+	abilityHandlersMutex.RLock()
+	defer abilityHandlersMutex.RUnlock()
+	for _, h := range abilityHandlers {
+		if m.HasAbility(h.GetAbility()) {
+			handlers = append(handlers, h)
+		}
+	}
+	return handlers
+}
+
+/* Replaces the handler of an ability in this game only (e.g. how an ability behaved in an older rules version) */
+func (g *Game) SetAbilityHandler(handler AbilityHandler) {
+	if g.abilityHandlerOverrides == nil {
+		g.abilityHandlerOverrides = make(map[Ability]AbilityHandler)
+	}
+	g.abilityHandlerOverrides[handler.GetAbility()] = handler
+	g.shareAbilityHandlerOverrides()
+}
+
+/* The stat getters of the cards don't know the game, so the monsters keep the replaced handlers too */
+func (g *Game) shareAbilityHandlerOverrides() {
+	for _, team := range []*GameTeam{g.team1, g.team2} {
+		if team == nil {
+			continue
+		}
+		for _, m := range team.GetMonstersList() {
+			m.abilityHandlerOverrides = g.abilityHandlerOverrides
+		}
+	}
+}
+
+/* Handler of the ability in this game */
+func (g *Game) GetAbilityHandler(ability Ability) AbilityHandler {
+	if h, ok := g.abilityHandlerOverrides[ability]; ok {
+		return h
+	}
+	return GetAbilityHandler(ability)
+}
+
+/* Same as getAbilityHandlersOf, with the handlers replaced in this game. Handlers of abilities that are not registered come last */
+func (g *Game) getAbilityHandlersOf(m *MonsterCard) []AbilityHandler {
+	handlers := getAbilityHandlersOf(m)
+	if len(g.abilityHandlerOverrides) == 0 || m == nil || len(m.Abilities) == 0 {
+		return handlers
+	}
+	for i, h := range handlers {
+		if override, ok := g.abilityHandlerOverrides[h.GetAbility()]; ok {
+			handlers[i] = override
+		}
+	}
+	// in the order of the abilities of the monster, the overrides map has no order
+	added := make([]Ability, 0)
+	for _, ability := range m.Abilities {
+		override, ok := g.abilityHandlerOverrides[ability]
+		if ok && !HasAbilityHandler(ability) && !utils.Contains(added, ability) {
+			handlers = append(handlers, override)
+			added = append(added, ability)
+		}
+	}
+	return handlers
+}
+
+func (c *MonsterCard) applyAbilityStatModifiers(stat Stat, value int) int {
+	if len(c.Abilities) == 0 {
+		return value
+	}
+	// called for every stat lookup, so don't build the handler list
+	abilityHandlersMutex.RLock()
+	defer abilityHandlersMutex.RUnlock()
+	for _, h := range abilityHandlers {
+		if !c.HasAbility(h.GetAbility()) {
+			continue
+		}
+		if override, ok := c.abilityHandlerOverrides[h.GetAbility()]; ok {
+			h = override
+		}
+		value = h.ModifyStat(c, stat, value)
+	}
+	if len(c.abilityHandlerOverrides) == 0 {
+		return value
+	}
+	// same order as getAbilityHandlersOf of the game: handlers of abilities that are not registered come last
+	for i, ability := range c.Abilities {
+		override, ok := c.abilityHandlerOverrides[ability]
+		if ok && !isAbilityRegistered(ability) && !utils.Contains(c.Abilities[:i], ability) {
+			value = override.ModifyStat(c, stat, value)
+		}
+	}
+	return value
+}
+
+/* Same as HasAbilityHandler, for callers that hold abilityHandlersMutex */
+func isAbilityRegistered(ability Ability) bool {
+	for _, h := range abilityHandlers {
+		if h.GetAbility() == ability {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Game) doAbilitiesPreGame(monsters []*MonsterCard) {
+	for _, m := range monsters {
+		for _, h := range g.getAbilityHandlersOf(m) {
+			h.OnPreGame(g, m)
+		}
+	}
+}
+
+func (g *Game) doAbilitiesPreTurn(m *MonsterCard) {
+	for _, h := range g.getAbilityHandlersOf(m) {
+		h.OnPreTurn(g, m)
+	}
+}
+
+func (g *Game) doAbilitiesOnAttack(hit *AbilityHitContext) {
+	for _, h := range g.getAbilityHandlersOf(hit.Attacker) {
+		h.OnAttack(g, hit.Attacker, hit)
+	}
+}
+
+/* Tells if an ability of the target absorbed the hit. Only the first absorbing ability is used up */
+func (g *Game) doAbilitiesBeforeBeingHit(hit *AbilityHitContext) bool {
+	handlers := getAbilityHandlersOf(hit.Target)
+	if g != nil {
+		handlers = g.getAbilityHandlersOf(hit.Target)
+	}
+	for _, h := range handlers {
+		if h.OnBeforeBeingHit(g, hit.Target, hit) {
+			hit.IsAbsorbed = true
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Game) doAbilitiesOnHit(hit *AbilityHitContext) {
+	for _, h := range g.getAbilityHandlersOf(hit.Attacker) {
+		h.OnHit(g, hit.Attacker, hit)
+	}
+}
+
+/* Runs the on-being-hit hooks of the target and records what they did as side effects of the hit */
+func (g *Game) doAbilitiesOnBeingHit(hit *AbilityHitContext) {
+	for _, h := range g.getAbilityHandlersOf(hit.Target) {
+		battleDamage := h.OnBeingHit(g, hit.Target, hit)
+		if battleDamage.Calculation != nil {
+			g.addDamageSideEffect(hit.Damage.Calculation, h.GetAbility(), hit.Attacker, battleDamage.Calculation.GetTotalDamage())
+		}
+	}
+}
+
+func (g *Game) doAbilitiesOnDeath(deadMonster *MonsterCard) {
+	for _, h := range g.getAbilityHandlersOf(deadMonster) {
+		h.OnDeath(g, deadMonster)
+	}
+}
+
+func (g *Game) doAbilitiesOnMonsterDeath(m *MonsterCard, deadMonster *MonsterCard) {
+	for _, h := range g.getAbilityHandlersOf(m) {
+		h.OnMonsterDeath(g, m, deadMonster)
+	}
+}
+
+func (g *Game) doAbilitiesPostRound(monsters []*MonsterCard) {
+	for _, m := range monsters {
+		if !m.IsAlive() {
+			continue
+		}
+		for _, h := range g.getAbilityHandlersOf(m) {
+			h.OnPostRound(g, m)
+		}
+	}
+}
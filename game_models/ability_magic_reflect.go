@@ -0,0 +1,37 @@
+package game_models
+
+import "math"
+
+/* Magic attackers take half of their magic attack (round up) as magic damage */
+type MagicReflectHandler struct {
+	BaseAbilityHandler
+}
+
+func (MagicReflectHandler) GetAbility() Ability {
+	return ABILITY_MAGIC_REFLECT
+}
+
+func (MagicReflectHandler) OnBeingHit(g *Game, owner *MonsterCard, hit *AbilityHitContext) BattleDamage {
+	attacker := hit.Attacker
+	if !owner.HasAbility(ABILITY_MAGIC_REFLECT) || hit.AttackType != ATTACK_TYPE_MAGIC {
+		return BattleDamage{}
+	}
+
+	// half of the magic attack damage (round up)
+	reflectDamage := int(math.Ceil(float64(hit.AttackDamage) / 2))
+
+	// Amplify
+	if attacker.HasDebuff(ABILITY_AMPLIFY) {
+		reflectDamage += 1
+	}
+
+	// Reflection shield
+	if attacker.HasAbility(ABILITY_REFLECTION_SHIELD) {
+		reflectDamage = 0
+	}
+
+	battleDamage := HitMonsterWithMagic(g, attacker, reflectDamage)
+	g.recordDamageCalculation(BATTLE_ACTION_MAGIC_REFLECT, owner, battleDamage)
+	g.CreateAndAddBattleLog(BATTLE_ACTION_MAGIC_REFLECT, attacker, owner, battleDamage.DamageDone)
+	return battleDamage
+}
@@ -0,0 +1,23 @@
+package game_models
+
+/* Hits every alive enemy for the redemption damage of the config (REDEMPTION_DAMAGE by default) when the owner dies */
+type RedemptionHandler struct {
+	BaseAbilityHandler
+}
+
+func (RedemptionHandler) GetAbility() Ability {
+	return ABILITY_REDEMPTION
+}
+
+func (RedemptionHandler) OnDeath(g *Game, owner *MonsterCard) {
+	for _, e := range g.GetEnemyTeamOfMonster(owner).GetAliveMonsters() {
+		battleDamage := HitMonsterWithPhysical(
+			g,
+			e,
+			g.GetConfig().RedemptionDamage,
+		)
+		g.recordDamageCalculation(AdditionalBattleAction(ABILITY_REDEMPTION), owner, battleDamage)
+
+		g.ProcessIfDead(e)
+	}
+}
@@ -0,0 +1,27 @@
+package game_models
+
+/* Has the retaliate chance of the config (RETALIATE_CHANCE by default) to attack back melee attackers */
+type RetaliateHandler struct {
+	BaseAbilityHandler
+}
+
+func (RetaliateHandler) GetAbility() Ability {
+	return ABILITY_RETALIATE
+}
+
+func (RetaliateHandler) OnBeingHit(g *Game, owner *MonsterCard, hit *AbilityHitContext) BattleDamage {
+	attacker := hit.Attacker
+	if attacker == nil || owner == nil || !owner.HasAbility(ABILITY_RETALIATE) || hit.AttackType != ATTACK_TYPE_MELEE {
+		return BattleDamage{}
+	}
+
+	// Retaliate chance
+	doesRetaliate := g.RollSuccessBelow(RANDOM_EVENT_RETALIATE, g.GetConfig().RetaliateChance*100, owner, attacker)
+	if !doesRetaliate {
+		return BattleDamage{}
+	}
+
+	g.CreateAndAddBattleLog(BATTLE_ACTION_RETALIATE, owner, attacker, attacker.GetPostAbilityMelee())
+	g.AttackMonsterPhase(owner, attacker, ATTACK_TYPE_MELEE)
+	return BattleDamage{}
+}
@@ -0,0 +1,37 @@
+package game_models
+
+import "math"
+
+/* Ranged attackers take half of their ranged attack (round up) as physical damage */
+type ReturnFireHandler struct {
+	BaseAbilityHandler
+}
+
+func (ReturnFireHandler) GetAbility() Ability {
+	return ABILITY_RETURN_FIRE
+}
+
+func (ReturnFireHandler) OnBeingHit(g *Game, owner *MonsterCard, hit *AbilityHitContext) BattleDamage {
+	attacker := hit.Attacker
+	if !owner.HasAbility(ABILITY_RETURN_FIRE) || hit.AttackType != ATTACK_TYPE_RANGED {
+		return BattleDamage{}
+	}
+
+	// half of the ranged attack damage (round up)
+	reflectDamage := int(math.Ceil(float64(hit.AttackDamage) / 2))
+
+	// Amplify
+	if attacker.HasDebuff(ABILITY_AMPLIFY) {
+		reflectDamage += 1
+	}
+
+	// Reflection shield
+	if attacker.HasAbility(ABILITY_REFLECTION_SHIELD) {
+		reflectDamage = 0
+	}
+
+	battleDamage := HitMonsterWithPhysical(g, attacker, reflectDamage)
+	g.recordDamageCalculation(BATTLE_ACTION_RETURN_FIRE, owner, battleDamage)
+	g.CreateAndAddBattleLog(BATTLE_ACTION_RETURN_FIRE, attacker, owner, battleDamage.DamageDone)
+	return battleDamage
+}
@@ -0,0 +1,15 @@
+package game_models
+
+/* Gains a Scavenger buff (+max health) whenever another monster dies */
+type ScavengerHandler struct {
+	BaseAbilityHandler
+}
+
+func (ScavengerHandler) GetAbility() Ability {
+	return ABILITY_SCAVENGER
+}
+
+func (ScavengerHandler) OnMonsterDeath(g *Game, owner *MonsterCard, deadMonster *MonsterCard) {
+	owner.AddBuff(ABILITY_SCAVENGER)
+	g.CreateAndAddBattleLog(BATTLE_ACTION_SCAVENGER, owner, deadMonster, 1)
+}
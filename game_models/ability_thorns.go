@@ -0,0 +1,35 @@
+package game_models
+
+/* Melee attackers take the thorns damage of the config (THORNS_DAMAGE by default) */
+type ThornsHandler struct {
+	BaseAbilityHandler
+}
+
+func (ThornsHandler) GetAbility() Ability {
+	return ABILITY_THORNS
+}
+
+func (ThornsHandler) OnBeingHit(g *Game, owner *MonsterCard, hit *AbilityHitContext) BattleDamage {
+	attacker := hit.Attacker
+	if !owner.HasAbility(ABILITY_THORNS) || hit.AttackType != ATTACK_TYPE_MELEE {
+		return BattleDamage{}
+	}
+
+	// Thorns always do the same damage amount
+	reflectDamage := g.GetConfig().ThornsDamage
+
+	// Amplify
+	if attacker.HasDebuff(ABILITY_AMPLIFY) {
+		reflectDamage += 1
+	}
+
+	// Reflection shield
+	if attacker.HasAbility(ABILITY_REFLECTION_SHIELD) {
+		reflectDamage = 0
+	}
+
+	battleDamage := HitMonsterWithPhysical(g, attacker, reflectDamage)
+	g.recordDamageCalculation(BATTLE_ACTION_THORNS, owner, battleDamage)
+	g.CreateAndAddBattleLog(BATTLE_ACTION_THORNS, attacker, owner, battleDamage.DamageDone)
+	return battleDamage
+}
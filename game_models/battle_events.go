@@ -0,0 +1,76 @@
+package game_models
+
+type BattleEventType string
+
+const (
+	BATTLE_EVENT_TURN_TAKEN   BattleEventType = "Turn taken"
+	BATTLE_EVENT_TURN_STUNNED BattleEventType = "Turn stunned"
+	BATTLE_EVENT_DAMAGE       BattleEventType = "Damage"
+	BATTLE_EVENT_DODGE        BattleEventType = "Dodge"
+	BATTLE_EVENT_DEATH        BattleEventType = "Death"
+	BATTLE_EVENT_RESURRECT    BattleEventType = "Resurrect"
+	BATTLE_EVENT_HEAL         BattleEventType = "Heal"
+	BATTLE_EVENT_REPAIR       BattleEventType = "Repair"
+	BATTLE_EVENT_CLEANSE      BattleEventType = "Cleanse"
+	BATTLE_EVENT_ROUND_START  BattleEventType = "Round start"
+)
+
+/*
+Structured event emitted by the game while it plays. Unlike BattleLog, events are
+emitted even when the game doesn't log, and Actor / Target are the live cards (not snapshots).
+  - Turn taken / Turn stunned: Actor is the monster whose turn it is
+  - Damage: Actor dealt the damage (nil for earthquake, poison and fatigue), Target took it
+  - Dodge: Actor dodged the attack of Target
+  - Death: Actor is the card that dealt the last damage (nil if none), Target died
+  - Heal / Repair / Cleanse / Resurrect: Actor is the caster, Target received it
+  - Round start: before fatigue and the pre round actions of every round, Round is the round about to be played
+*/
+type BattleEvent struct {
+	Type   BattleEventType
+	Round  int
+	Source AdditionalBattleAction
+	Actor  GameCardInterface
+	Target GameCardInterface
+	Value  int
+	Damage *DamageCalculation
+}
+
+type BattleEventListener interface {
+	OnBattleEvent(event BattleEvent)
+}
+
+func (g *Game) AddEventListener(listener BattleEventListener) {
+	g.eventListeners = append(g.eventListeners, listener)
+}
+
+func (g *Game) emitEvent(event BattleEvent) {
+	if len(g.eventListeners) == 0 {
+		return
+	}
+	event.Round = g.roundNumber
+	for _, listener := range g.eventListeners {
+		listener.OnBattleEvent(event)
+	}
+}
+
+/* Emits a damage event that is not part of a hit (poison, fatigue, piercing remainder) */
+func (g *Game) emitHealthDamageEvent(source AdditionalBattleAction, attackType CardAttackType, attacker GameCardInterface, target *MonsterCard, healthDamage int) {
+	if len(g.eventListeners) == 0 {
+		return
+	}
+	if attacker != nil {
+		g.lastDamagedBy[target] = attacker
+	} else {
+		delete(g.lastDamagedBy, target)
+	}
+	calc := &DamageCalculation{
+		Round:          g.roundNumber,
+		Source:         source,
+		AttackType:     attackType,
+		Attacker:       attacker,
+		Target:         target,
+		IncomingDamage: healthDamage,
+		HealthDamage:   healthDamage,
+	}
+	g.emitEvent(BattleEvent{Type: BATTLE_EVENT_DAMAGE, Source: source, Actor: attacker, Target: target, Value: healthDamage, Damage: calc})
+}
@@ -0,0 +1,205 @@
+package game_models
+
+import (
+	"fmt"
+	"sort"
+)
+
+/** What a single monster did in one battle */
+type MonsterBattleStats struct {
+	Team               TeamNumber
+	Position           int
+	Name               string
+	CardDetailID       int
+	DamageDealt        map[CardAttackType]int // damage of melee / ranged / magic attacks including blast and piercing
+	AbilityDamageDealt int                    // damage of Thorns, Magic Reflect, Return Fire, Redemption, etc.
+	ArmorDamageTaken   int
+	HealthDamageTaken  int
+	Kills              int
+	HealingGiven       int
+	RepairGiven        int
+	TurnsTaken         int
+	TurnsStunned       int
+	Dodges             int
+	DeathRound         int // round (starting 1) the monster died in, 0 if it survived
+}
+
+/** What a summoner did in one battle (Tank Heal, Triage, Repair, Cleanse) */
+type SummonerBattleStats struct {
+	Team         TeamNumber
+	Name         string
+	CardDetailID int
+	HealingGiven int
+	RepairGiven  int
+	Cleanses     int
+}
+
+func (s MonsterBattleStats) GetTotalDamageDealt() int {
+	total := s.AbilityDamageDealt
+	for _, damage := range s.DamageDealt {
+		total += damage
+	}
+	return total
+}
+
+/* Collects MonsterBattleStats and SummonerBattleStats from the events of a game. Add it to the game with AddEventListener. */
+type BattleStatsCollector struct {
+	monsterStats  map[string]*MonsterBattleStats
+	summonerStats map[TeamNumber]*SummonerBattleStats
+}
+
+func NewBattleStatsCollector() *BattleStatsCollector {
+	return &BattleStatsCollector{
+		monsterStats:  make(map[string]*MonsterBattleStats),
+		summonerStats: make(map[TeamNumber]*SummonerBattleStats),
+	}
+}
+
+func (c *BattleStatsCollector) OnBattleEvent(event BattleEvent) {
+	switch event.Type {
+	case BATTLE_EVENT_TURN_TAKEN:
+		if stats := c.getMonsterStats(event.Actor); stats != nil {
+			stats.TurnsTaken += 1
+		}
+	case BATTLE_EVENT_TURN_STUNNED:
+		if stats := c.getMonsterStats(event.Actor); stats != nil {
+			stats.TurnsStunned += 1
+		}
+	case BATTLE_EVENT_DODGE:
+		if stats := c.getMonsterStats(event.Actor); stats != nil {
+			stats.Dodges += 1
+		}
+	case BATTLE_EVENT_DAMAGE:
+		c.onDamage(event)
+	case BATTLE_EVENT_DEATH:
+		if stats := c.getMonsterStats(event.Target); stats != nil {
+			stats.DeathRound = event.Round + 1
+		}
+		if stats := c.getMonsterStats(event.Actor); stats != nil && event.Actor.GetTeamNumber() != event.Target.GetTeamNumber() {
+			stats.Kills += 1
+		}
+	case BATTLE_EVENT_RESURRECT:
+		if stats := c.getMonsterStats(event.Target); stats != nil {
+			stats.DeathRound = 0
+		}
+	case BATTLE_EVENT_HEAL:
+		if stats := c.getMonsterStats(event.Actor); stats != nil {
+			stats.HealingGiven += event.Value
+		} else if stats := c.getSummonerStats(event.Actor); stats != nil {
+			stats.HealingGiven += event.Value
+		}
+	case BATTLE_EVENT_REPAIR:
+		if stats := c.getMonsterStats(event.Actor); stats != nil {
+			stats.RepairGiven += event.Value
+		} else if stats := c.getSummonerStats(event.Actor); stats != nil {
+			stats.RepairGiven += event.Value
+		}
+	case BATTLE_EVENT_CLEANSE:
+		if stats := c.getSummonerStats(event.Actor); stats != nil {
+			stats.Cleanses += 1
+		}
+	}
+}
+
+func (c *BattleStatsCollector) onDamage(event BattleEvent) {
+	calc := event.Damage
+	if calc == nil {
+		return
+	}
+	if stats := c.getMonsterStats(event.Target); stats != nil {
+		stats.ArmorDamageTaken += calc.ArmorDamage
+		stats.HealthDamageTaken += calc.HealthDamage
+	}
+
+	stats := c.getMonsterStats(event.Actor)
+	if stats == nil {
+		return
+	}
+	damage := calc.ArmorDamage + calc.HealthDamage
+	switch event.Source {
+	case BATTLE_ACTION_MELEE, BATTLE_ACTION_RANGED, BATTLE_ACTION_MAGIC, BATTLE_ACTION_BLAST, BATTLE_ACTION_PIERCING_REMAINDER:
+		stats.DamageDealt[calc.AttackType] += damage
+	default:
+		stats.AbilityDamageDealt += damage
+	}
+}
+
+func (c *BattleStatsCollector) getMonsterStats(card GameCardInterface) *MonsterBattleStats {
+	m, ok := card.(*MonsterCard)
+	if !ok || m == nil {
+		return nil
+	}
+	return c.getMonsterStatsOfPosition(m, m.GetCardPosition())
+}
+
+func (c *BattleStatsCollector) getMonsterStatsOfPosition(m *MonsterCard, position int) *MonsterBattleStats {
+	key := GetMonsterStatsKey(m.GetTeamNumber(), position)
+	if stats, ok := c.monsterStats[key]; ok {
+		return stats
+	}
+	stats := &MonsterBattleStats{
+		Team:         m.GetTeamNumber(),
+		Position:     position,
+		Name:         m.GetName(),
+		CardDetailID: m.cardDetail.ID,
+		DamageDealt:  make(map[CardAttackType]int),
+	}
+	c.monsterStats[key] = stats
+	return stats
+}
+
+func (c *BattleStatsCollector) getSummonerStats(card GameCardInterface) *SummonerBattleStats {
+	s, ok := card.(*SummonerCard)
+	if !ok || s == nil {
+		return nil
+	}
+	if stats, ok := c.summonerStats[s.GetTeamNumber()]; ok {
+		return stats
+	}
+	stats := &SummonerBattleStats{Team: s.GetTeamNumber(), Name: s.GetName(), CardDetailID: s.cardDetail.ID}
+	c.summonerStats[s.GetTeamNumber()] = stats
+	return stats
+}
+
+/** Registers every monster and summoner of the game so ones that did nothing are still reported */
+func (c *BattleStatsCollector) AddGameCards(g *Game) {
+	for _, team := range []*GameTeam{g.team1, g.team2} {
+		c.getSummonerStats(team.GetSummoner())
+		// positions are only set when the game starts, so use the index in the team
+		for i, m := range team.GetMonstersList() {
+			c.getMonsterStatsOfPosition(m, i)
+		}
+	}
+}
+
+/** Monster stats sorted by team and position */
+func (c *BattleStatsCollector) GetMonsterStats() []MonsterBattleStats {
+	statsList := make([]MonsterBattleStats, 0)
+	for _, stats := range c.monsterStats {
+		statsList = append(statsList, *stats)
+	}
+	sort.Slice(statsList, func(i, j int) bool {
+		if statsList[i].Team != statsList[j].Team {
+			return statsList[i].Team < statsList[j].Team
+		}
+		return statsList[i].Position < statsList[j].Position
+	})
+	return statsList
+}
+
+/** Summoner stats sorted by team */
+func (c *BattleStatsCollector) GetSummonerStats() []SummonerBattleStats {
+	statsList := make([]SummonerBattleStats, 0)
+	for _, stats := range c.summonerStats {
+		statsList = append(statsList, *stats)
+	}
+	sort.Slice(statsList, func(i, j int) bool {
+		return statsList[i].Team < statsList[j].Team
+	})
+	return statsList
+}
+
+/** Key of a monster that stays the same between games of the same teams, e.g. "1-0" */
+func GetMonsterStatsKey(team TeamNumber, position int) string {
+	return fmt.Sprintf("%d-%d", team, position)
+}
@@ -0,0 +1,197 @@
+package game_models
+
+import (
+	"fmt"
+	"math"
+)
+
+/* A card of the cards/get_details payload that couldn't be decoded */
+type CardDecodeError struct {
+	CardDetailID int
+	Name         string
+	Field        string
+	Reason       string
+}
+
+func (e *CardDecodeError) Error() string {
+	return fmt.Sprintf("card %d (%s): %s: %s", e.CardDetailID, e.Name, e.Field, e.Reason)
+}
+
+/*
+Typed stats of a card. Monsters have a stat per level, summoners have flat stats.
+Abilities are per level for both (a flat ability list of a summoner is its level 1 abilities).
+*/
+type DecodedCardStats struct {
+	IsSummoner    bool
+	MonsterStats  CardStatsByLevel
+	SummonerStats FlatCardStats
+	Abilities     [][]Ability
+}
+
+/* Number of levels the stats of a monster have */
+func (d DecodedCardStats) GetLevelCount() int {
+	return len(d.MonsterStats.Health)
+}
+
+/* Abilities the card has at the level (starting 1) */
+func (d DecodedCardStats) GetAbilitiesOfLevel(level int) []Ability {
+	abilities := make([]Ability, 0)
+	for i, abilitiesInLevel := range d.Abilities {
+		if i+1 > level {
+			break
+		}
+		abilities = append(abilities, abilitiesInLevel...)
+	}
+	return abilities
+}
+
+/* Decodes the raw stats of the card. Monsters and summoners are told apart by the shape of the health stat (array or number). */
+func DecodeCardStats(cardDetail CardDetail) (DecodedCardStats, error) {
+	var decoded DecodedCardStats
+	decodeErr := func(field, reason string, args ...any) error {
+		return &CardDecodeError{CardDetailID: cardDetail.ID, Name: cardDetail.Name, Field: field, Reason: fmt.Sprintf(reason, args...)}
+	}
+
+	stats := cardDetail.Stats
+	if _, isArray := stats.Health.([]any); !isArray {
+		decoded.IsSummoner = true
+		fields := []struct {
+			name  string
+			value any
+			stat  *int
+		}{
+			{"mana", stats.Mana, &decoded.SummonerStats.Mana},
+			{"attack", stats.Attack, &decoded.SummonerStats.Attack},
+			{"ranged", stats.Ranged, &decoded.SummonerStats.Ranged},
+			{"magic", stats.Magic, &decoded.SummonerStats.Magic},
+			{"armor", stats.Armor, &decoded.SummonerStats.Armor},
+			{"health", stats.Health, &decoded.SummonerStats.Health},
+			{"speed", stats.Speed, &decoded.SummonerStats.Speed},
+		}
+		for _, f := range fields {
+			value, err := decodeInt(f.value)
+			if err != nil {
+				return decoded, decodeErr(f.name, "%s", err)
+			}
+			*f.stat = value
+		}
+	} else {
+		fields := []struct {
+			name  string
+			value any
+			stat  *[]int
+		}{
+			{"mana", stats.Mana, &decoded.MonsterStats.Mana},
+			{"attack", stats.Attack, &decoded.MonsterStats.Attack},
+			{"ranged", stats.Ranged, &decoded.MonsterStats.Ranged},
+			{"magic", stats.Magic, &decoded.MonsterStats.Magic},
+			{"armor", stats.Armor, &decoded.MonsterStats.Armor},
+			{"health", stats.Health, &decoded.MonsterStats.Health},
+			{"speed", stats.Speed, &decoded.MonsterStats.Speed},
+		}
+		levelCount := len(stats.Health.([]any))
+		maxLevel := GetMaxLevelOfRarity(cardDetail.Rarity)
+		if levelCount == 0 {
+			return decoded, decodeErr("health", "no levels")
+		}
+		if maxLevel > 0 && levelCount > maxLevel {
+			return decoded, decodeErr("health", "%d levels but max level of rarity %d is %d", levelCount, cardDetail.Rarity, maxLevel)
+		}
+		for _, f := range fields {
+			values, ok := f.value.([]any)
+			if !ok {
+				return decoded, decodeErr(f.name, "expected a stat per level, got %T", f.value)
+			}
+			if len(values) != levelCount {
+				return decoded, decodeErr(f.name, "%d levels but health has %d", len(values), levelCount)
+			}
+			*f.stat = make([]int, 0, levelCount)
+			for i, v := range values {
+				value, err := decodeInt(v)
+				if err != nil {
+					return decoded, decodeErr(f.name, "level %d: %s", i+1, err)
+				}
+				*f.stat = append(*f.stat, value)
+			}
+		}
+	}
+
+	abilities, err := decodeAbilities(stats.Abilities, decoded.IsSummoner)
+	if err != nil {
+		return decoded, decodeErr("abilities", "%s", err)
+	}
+	decoded.Abilities = abilities
+	if !decoded.IsSummoner {
+		if len(abilities) > decoded.GetLevelCount() {
+			return decoded, decodeErr("abilities", "%d levels but health has %d", len(abilities), decoded.GetLevelCount())
+		}
+		decoded.MonsterStats.Abilities = abilities
+	}
+	return decoded, nil
+}
+
+/* Decodes every card, returns the valid ones per id and an error for each malformed one */
+func DecodeCardDetails(cardDetails []CardDetail) (CardDetailMap, []error) {
+	cardDetailMap := make(CardDetailMap)
+	errs := make([]error, 0)
+	for _, cd := range cardDetails {
+		if _, err := DecodeCardStats(cd); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		cardDetailMap[cd.ID] = cd
+	}
+	return cardDetailMap, errs
+}
+
+func decodeInt(value any) (int, error) {
+	switch v := value.(type) {
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("%v is not an integer", v)
+		}
+		return int(v), nil
+	case int:
+		return v, nil
+	case nil:
+		return 0, fmt.Errorf("missing")
+	default:
+		return 0, fmt.Errorf("expected a number, got %T", value)
+	}
+}
+
+/* Abilities are a list per level ([["Shield"], [], ["Void"]]). Summoners may have a flat list (["Void"]), which is their level 1 abilities. */
+func decodeAbilities(rawAbilities []any, isSummoner bool) ([][]Ability, error) {
+	abilities := make([][]Ability, 0)
+	flatAbilities := make([]Ability, 0)
+	for i, rawLevel := range rawAbilities {
+		switch level := rawLevel.(type) {
+		case string:
+			if !isSummoner {
+				return nil, fmt.Errorf("level %d: expected a list of abilities, got %q", i+1, level)
+			}
+			if level != "" {
+				flatAbilities = append(flatAbilities, Ability(level))
+			}
+		case []any:
+			abilitiesInLevel := make([]Ability, 0)
+			for _, rawAbility := range level {
+				ability, ok := rawAbility.(string)
+				if !ok {
+					return nil, fmt.Errorf("level %d: expected an ability name, got %T", i+1, rawAbility)
+				}
+				abilitiesInLevel = append(abilitiesInLevel, Ability(ability))
+			}
+			abilities = append(abilities, abilitiesInLevel)
+		default:
+			return nil, fmt.Errorf("level %d: unexpected %T", i+1, rawLevel)
+		}
+	}
+	if len(flatAbilities) > 0 {
+		if len(abilities) > 0 {
+			return nil, fmt.Errorf("mixed flat and per level abilities")
+		}
+		abilities = append(abilities, flatAbilities)
+	}
+	return abilities, nil
+}
@@ -0,0 +1,128 @@
+package game_models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	utils "github.com/YukiUmetsu/go-spl-simulator/game_utils"
+)
+
+/* Parses a comma separated edition list (e.g. CardDetail.Editions "7,8") */
+func ParseEditions(editionsStr string) ([]CardEdition, error) {
+	editions := make([]CardEdition, 0)
+	for _, e := range strings.Split(editionsStr, ",") {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		edition, err := strconv.Atoi(e)
+		if err != nil || edition < 0 {
+			return editions, fmt.Errorf("invalid edition %q in %q", e, editionsStr)
+		}
+		editions = append(editions, CardEdition(edition))
+	}
+	return editions, nil
+}
+
+/* Editions the card was printed in. Invalid editions are left out */
+func GetEditionsOfCard(cardDetail CardDetail) []CardEdition {
+	editions, _ := ParseEditions(cardDetail.Editions)
+	return editions
+}
+
+const (
+	FORMAT_WILD   = "Wild"
+	FORMAT_MODERN = "Modern"
+)
+
+/* Sets legal in Modern. Reward and promo cards are legal from MODERN_FIRST_TIER */
+var MODERN_EDITIONS = []CardEdition{GLADIUS, CHAOS, RIFTWATCHERS, SOULBOUND, REBELLION, REBELLION_REWARD, CONCLAVE_ARCANA}
+
+/* Tier of the first reward and promo cards legal in Modern (the Chaos Legion ones) */
+const MODERN_FIRST_TIER = 7
+
+/*
+Cards that can be played. A card is legal if one of its editions is allowed.
+Reward and promo cards are legal if their edition is allowed, or if their tier is at least MinRewardTier (0: not by tier).
+No editions (Wild) allows every card.
+*/
+type CardFormat struct {
+	Name          string
+	Editions      []CardEdition
+	MinRewardTier int
+}
+
+func NewWildFormat() CardFormat {
+	return CardFormat{Name: FORMAT_WILD}
+}
+
+func NewModernFormat() CardFormat {
+	return CardFormat{Name: FORMAT_MODERN, Editions: MODERN_EDITIONS, MinRewardTier: MODERN_FIRST_TIER}
+}
+
+/* Format of a brawl or tournament that only allows some editions */
+func NewCustomFormat(name string, allowedCards BattleAllowedCards) CardFormat {
+	editions := make([]CardEdition, 0, len(allowedCards.Editions))
+	for _, e := range allowedCards.Editions {
+		editions = append(editions, CardEdition(e))
+	}
+	return CardFormat{Name: name, Editions: editions}
+}
+
+func (f CardFormat) IsEditionAllowed(edition CardEdition) bool {
+	return len(f.Editions) == 0 || utils.Contains(f.Editions, edition)
+}
+
+func (f CardFormat) IsCardLegal(cardDetail CardDetail) bool {
+	if len(f.Editions) == 0 {
+		return true
+	}
+	for _, edition := range GetEditionsOfCard(cardDetail) {
+		if f.IsEditionAllowed(edition) {
+			return true
+		}
+		isRewardOrPromo := edition == REWARD || edition == PROMO
+		if isRewardOrPromo && f.MinRewardTier > 0 && cardDetail.Tier >= f.MinRewardTier {
+			return true
+		}
+	}
+	return false
+}
+
+/* Cards of the map legal in the format */
+func (f CardFormat) FilterCardDetails(cardDetailMap CardDetailMap) CardDetailMap {
+	legalCards := make(CardDetailMap)
+	for id, cd := range cardDetailMap {
+		if f.IsCardLegal(cd) {
+			legalCards[id] = cd
+		}
+	}
+	return legalCards
+}
+
+/* A card of the team that is not legal in the format */
+type FormatRestrictionError struct {
+	Format       string
+	CardDetailID int
+	CardName     string
+}
+
+func (e *FormatRestrictionError) Error() string {
+	return fmt.Sprintf("%s: %s (%d) is not legal", e.Format, e.CardName, e.CardDetailID)
+}
+
+/* Cards of the team that are not legal in the format */
+func (f CardFormat) ValidateTeam(team *GameTeam) []error {
+	errs := make([]error, 0)
+	summoner := team.GetSummoner()
+	if summoner != nil && !f.IsCardLegal(summoner.GetCardDetail()) {
+		errs = append(errs, &FormatRestrictionError{Format: f.Name, CardDetailID: summoner.GetCardDetail().ID, CardName: summoner.GetName()})
+	}
+	for _, m := range team.GetMonstersList() {
+		if !f.IsCardLegal(m.GetCardDetail()) {
+			errs = append(errs, &FormatRestrictionError{Format: f.Name, CardDetailID: m.GetCardDetail().ID, CardName: m.GetName()})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,104 @@
+package game_models
+
+/* XP needed to reach level 2, 3, ... of legacy cards (per rarity) */
+var LEGACY_XP_LEVELS = [][]int{
+	{20, 60, 160, 360, 760, 1560, 2560, 4560, 7560},
+	{100, 300, 700, 1500, 2500, 4500, 8500},
+	{250, 750, 1750, 3750, 7750},
+	{1000, 3000, 7000},
+}
+
+/* XP of a single gold foil card of legacy editions (per rarity). Alpha cards are worth more than the later legacy editions */
+var LEGACY_GOLD_XP = [][]int{
+	{250, 500, 1000, 2500},
+	{200, 400, 800, 2000},
+}
+
+/* Number of regular cards (BCX) needed to reach level 1, 2, ... (per rarity) */
+var COMBINE_RATES = [][]int{
+	{1, 5, 14, 30, 60, 100, 150, 220, 300, 400},
+	{1, 5, 14, 25, 40, 60, 85, 115},
+	{1, 4, 10, 20, 32, 46},
+	{1, 3, 6, 11},
+}
+
+/* Number of gold foil cards (BCX) needed to reach level 1, 2, ... (per rarity) */
+var COMBINE_RATES_GOLD = [][]int{
+	{0, 0, 1, 2, 5, 9, 14, 20, 27, 38},
+	{0, 1, 2, 4, 7, 11, 16, 22},
+	{0, 1, 2, 4, 7, 10},
+	{0, 1, 2, 4},
+}
+
+const (
+	// cards of this edition or later (and reward cards of this tier or later) count BCX instead of XP
+	FIRST_BCX_EDITION = 4
+	FIRST_BCX_TIER    = 4
+)
+
+/* Tells if the xp of the card is the number of combined cards (BCX) instead of legacy XP */
+func IsBCXCard(cardDetail CardDetail, edition int) bool {
+	return edition >= FIRST_BCX_EDITION || cardDetail.Tier >= FIRST_BCX_TIER
+}
+
+/* Returns the level of a card from its xp, using the legacy XP levels or the combine rates depending on the edition. 0 if the rarity is unknown. */
+func GetCardLevelFromXP(cardDetail CardDetail, xp int, edition int, gold bool) int {
+	rarityIndex := cardDetail.Rarity - 1
+	if rarityIndex < 0 || rarityIndex >= len(COMBINE_RATES) {
+		return 0
+	}
+
+	if IsBCXCard(cardDetail, edition) {
+		rates := COMBINE_RATES[rarityIndex]
+		if gold {
+			rates = COMBINE_RATES_GOLD[rarityIndex]
+		}
+		level := 0
+		for _, rate := range rates {
+			if rate > xp {
+				break
+			}
+			level += 1
+		}
+		// a single gold card is always at least level 1
+		if level == 0 {
+			level = 1
+		}
+		return level
+	}
+
+	// the xp of a gold card is at least the xp of a single gold card, which starts above level 1
+	if gold {
+		goldXP := LEGACY_GOLD_XP[1][rarityIndex]
+		if edition == int(ALPHA) {
+			goldXP = LEGACY_GOLD_XP[0][rarityIndex]
+		}
+		if xp < goldXP {
+			xp = goldXP
+		}
+	}
+	levels := LEGACY_XP_LEVELS[rarityIndex]
+	for i, levelXP := range levels {
+		if xp < levelXP {
+			return i + 1
+		}
+	}
+	return len(levels) + 1
+}
+
+/*
+Highest level a monster of the rarity can be played at with the summoner.
+The summoner's share of its own max level applies to the monster's max level, rounded up.
+e.g. a level 1 legendary summoner (1/4) caps a common (max 10) at level 3
+*/
+func GetMonsterLevelCap(summonerRarity, summonerLevel, monsterRarity int) int {
+	summonerMaxLevel := GetMaxLevelOfRarity(summonerRarity)
+	monsterMaxLevel := GetMaxLevelOfRarity(monsterRarity)
+	if summonerMaxLevel == 0 || summonerLevel >= summonerMaxLevel {
+		return monsterMaxLevel
+	}
+	if summonerLevel < 1 {
+		summonerLevel = 1
+	}
+	return (summonerLevel*monsterMaxLevel + summonerMaxLevel - 1) / summonerMaxLevel
+}
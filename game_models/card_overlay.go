@@ -0,0 +1,284 @@
+package game_models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	utils "github.com/YukiUmetsu/go-spl-simulator/game_utils"
+	"gopkg.in/yaml.v3"
+)
+
+/*
+Changes to the stats of a card. Stats are named like the card details stats (mana, attack, ranged, magic, armor, health, speed).
+  - Levels: levels (starting 1) the change applies to, every level if empty. Summoners have flat stats, so levels only apply to their abilities.
+    Abilities are added at level 1 if empty.
+  - Add: added to the stat (e.g. {"speed": 1})
+  - Set: replaces the stat, applied after Add
+  - AddAbilities: abilities unlocked at the levels
+  - RemoveAbilities: abilities the card loses at the levels. Abilities are kept at higher levels,
+    so they are lost at every level up to the highest one and unlocked again at the level after.
+*/
+type CardStatOverride struct {
+	CardDetailID    int            `json:"card_detail_id" yaml:"card_detail_id"`
+	Levels          []int          `json:"levels,omitempty" yaml:"levels,omitempty"`
+	Add             map[string]int `json:"add,omitempty" yaml:"add,omitempty"`
+	Set             map[string]int `json:"set,omitempty" yaml:"set,omitempty"`
+	AddAbilities    []Ability      `json:"add_abilities,omitempty" yaml:"add_abilities,omitempty"`
+	RemoveAbilities []Ability      `json:"remove_abilities,omitempty" yaml:"remove_abilities,omitempty"`
+}
+
+/* Balance changes to apply on top of a card catalog, e.g. a proposed nerf */
+type CardOverlay struct {
+	Name  string             `json:"name" yaml:"name"`
+	Cards []CardStatOverride `json:"cards" yaml:"cards"`
+}
+
+/* Parses a JSON or YAML (.yaml, .yml) overlay */
+func LoadCardOverlay(path string) (CardOverlay, error) {
+	var overlay CardOverlay
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return overlay, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &overlay)
+	default:
+		err = json.Unmarshal(data, &overlay)
+	}
+	return overlay, err
+}
+
+/* Returns a copy of the catalog with the overlay applied. The catalog is not modified. */
+func (o CardOverlay) Apply(cardDetailMap CardDetailMap) (CardDetailMap, error) {
+	overlaid := make(CardDetailMap, len(cardDetailMap))
+	for id, cd := range cardDetailMap {
+		overlaid[id] = cd
+	}
+	for _, override := range o.Cards {
+		cardDetail, ok := overlaid[override.CardDetailID]
+		if !ok {
+			return cardDetailMap, fmt.Errorf("overlay %s: unknown card %d", o.Name, override.CardDetailID)
+		}
+		cardDetail, err := override.Apply(cardDetail)
+		if err != nil {
+			return cardDetailMap, fmt.Errorf("overlay %s: %w", o.Name, err)
+		}
+		overlaid[override.CardDetailID] = cardDetail
+	}
+	return overlaid, nil
+}
+
+/* Returns the card with the changes applied */
+func (o CardStatOverride) Apply(cardDetail CardDetail) (CardDetail, error) {
+	decoded, err := DecodeCardStats(cardDetail)
+	if err != nil {
+		return cardDetail, err
+	}
+	overrideErr := func(reason string, args ...any) error {
+		return &CardDecodeError{CardDetailID: cardDetail.ID, Name: cardDetail.Name, Field: "overlay", Reason: fmt.Sprintf(reason, args...)}
+	}
+
+	maxLevel := decoded.GetLevelCount()
+	if decoded.IsSummoner {
+		maxLevel = GetMaxLevelOfRarity(cardDetail.Rarity)
+	}
+	for _, level := range o.Levels {
+		if level < 1 || level > maxLevel {
+			return cardDetail, overrideErr("level %d out of 1-%d", level, maxLevel)
+		}
+	}
+	levels := o.Levels
+	if len(levels) == 0 {
+		for level := 1; level <= decoded.GetLevelCount(); level++ {
+			levels = append(levels, level)
+		}
+	}
+
+	// stats
+	for _, changes := range []struct {
+		values map[string]int
+		apply  func(current, value int) int
+	}{
+		{o.Add, func(current, value int) int { return current + value }},
+		{o.Set, func(current, value int) int { return value }},
+	} {
+		for name, value := range changes.values {
+			if decoded.IsSummoner {
+				stat := getFlatStat(&decoded.SummonerStats, name)
+				if stat == nil {
+					return cardDetail, overrideErr("unknown stat %q", name)
+				}
+				*stat = changes.apply(*stat, value)
+				continue
+			}
+			stats := getStatByLevel(&decoded.MonsterStats, name)
+			if stats == nil {
+				return cardDetail, overrideErr("unknown stat %q", name)
+			}
+			for _, level := range levels {
+				(*stats)[level-1] = changes.apply((*stats)[level-1], value)
+			}
+		}
+	}
+
+	// abilities, removed from every level and added from level 1 by default
+	removeLevels := o.Levels
+	if len(removeLevels) == 0 {
+		for level := 1; level <= maxLevel; level++ {
+			removeLevels = append(removeLevels, level)
+		}
+	}
+	for _, a := range o.RemoveAbilities {
+		if err := removeAbility(&decoded, a, removeLevels, maxLevel); err != nil {
+			return cardDetail, overrideErr("%s", err.Error())
+		}
+	}
+	addLevels := o.Levels
+	if len(addLevels) == 0 {
+		addLevels = []int{1}
+	}
+	for _, level := range addLevels {
+		if len(o.AddAbilities) == 0 {
+			break
+		}
+		for len(decoded.Abilities) < level {
+			decoded.Abilities = append(decoded.Abilities, []Ability{})
+		}
+		for _, a := range o.AddAbilities {
+			if !utils.Contains(decoded.Abilities[level-1], a) {
+				decoded.Abilities[level-1] = append(decoded.Abilities[level-1], a)
+			}
+		}
+	}
+
+	cardDetail.Stats = EncodeCardStats(decoded)
+	return cardDetail, nil
+}
+
+/*
+Removes the ability at the levels. Abilities unlocked at a level are kept at the levels above,
+so it is removed from the unlock lists up to the highest level and unlocked again at the level after if the card had it there.
+Error if the card doesn't have the ability at any of the levels.
+*/
+func removeAbility(decoded *DecodedCardStats, ability Ability, levels []int, maxLevel int) error {
+	highestLevel := 0
+	hasAbility := false
+	for _, level := range levels {
+		highestLevel = utils.GetBigger(highestLevel, level)
+		hasAbility = hasAbility || utils.Contains(decoded.GetAbilitiesOfLevel(level), ability)
+	}
+	if !hasAbility {
+		return fmt.Errorf("%s is not unlocked at levels %v", ability, levels)
+	}
+	hasAbilityAbove := highestLevel < maxLevel && utils.Contains(decoded.GetAbilitiesOfLevel(highestLevel+1), ability)
+
+	for level := 1; level <= highestLevel && level <= len(decoded.Abilities); level++ {
+		abilities := make([]Ability, 0, len(decoded.Abilities[level-1]))
+		for _, a := range decoded.Abilities[level-1] {
+			if a != ability {
+				abilities = append(abilities, a)
+			}
+		}
+		decoded.Abilities[level-1] = abilities
+	}
+	if hasAbilityAbove {
+		for len(decoded.Abilities) <= highestLevel {
+			decoded.Abilities = append(decoded.Abilities, []Ability{})
+		}
+		if !utils.Contains(decoded.Abilities[highestLevel], ability) {
+			decoded.Abilities[highestLevel] = append(decoded.Abilities[highestLevel], ability)
+		}
+	}
+	return nil
+}
+
+/* Raw stats (as in the cards/get_details payload) of decoded stats */
+func EncodeCardStats(decoded DecodedCardStats) CardRawStats {
+	var raw CardRawStats
+	if decoded.IsSummoner {
+		stats := decoded.SummonerStats
+		raw = CardRawStats{
+			Mana:   float64(stats.Mana),
+			Attack: float64(stats.Attack),
+			Ranged: float64(stats.Ranged),
+			Magic:  float64(stats.Magic),
+			Armor:  float64(stats.Armor),
+			Health: float64(stats.Health),
+			Speed:  float64(stats.Speed),
+		}
+	} else {
+		stats := decoded.MonsterStats
+		raw = CardRawStats{
+			Mana:   encodeStatByLevel(stats.Mana),
+			Attack: encodeStatByLevel(stats.Attack),
+			Ranged: encodeStatByLevel(stats.Ranged),
+			Magic:  encodeStatByLevel(stats.Magic),
+			Armor:  encodeStatByLevel(stats.Armor),
+			Health: encodeStatByLevel(stats.Health),
+			Speed:  encodeStatByLevel(stats.Speed),
+		}
+	}
+
+	raw.Abilities = make([]any, 0, len(decoded.Abilities))
+	for _, abilitiesInLevel := range decoded.Abilities {
+		level := make([]any, 0, len(abilitiesInLevel))
+		for _, a := range abilitiesInLevel {
+			level = append(level, string(a))
+		}
+		raw.Abilities = append(raw.Abilities, level)
+	}
+	return raw
+}
+
+func encodeStatByLevel(stats []int) []any {
+	values := make([]any, 0, len(stats))
+	for _, v := range stats {
+		values = append(values, float64(v))
+	}
+	return values
+}
+
+func getFlatStat(stats *FlatCardStats, name string) *int {
+	switch name {
+	case "mana":
+		return &stats.Mana
+	case "attack":
+		return &stats.Attack
+	case "ranged":
+		return &stats.Ranged
+	case "magic":
+		return &stats.Magic
+	case "armor":
+		return &stats.Armor
+	case "health":
+		return &stats.Health
+	case "speed":
+		return &stats.Speed
+	}
+	return nil
+}
+
+func getStatByLevel(stats *CardStatsByLevel, name string) *[]int {
+	switch name {
+	case "mana":
+		return &stats.Mana
+	case "attack":
+		return &stats.Attack
+	case "ranged":
+		return &stats.Ranged
+	case "magic":
+		return &stats.Magic
+	case "armor":
+		return &stats.Armor
+	case "health":
+		return &stats.Health
+	case "speed":
+		return &stats.Speed
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package game_models
+
+import (
+	"sort"
+
+	utils "github.com/YukiUmetsu/go-spl-simulator/game_utils"
+)
+
+/* A stat of a card that changed at a level (0 for the flat stats of summoners). Missing levels count as 0. */
+type CardStatChange struct {
+	Stat   string
+	Level  int
+	Before int
+	After  int
+}
+
+/* An ability unlocked at a level that was added or removed */
+type CardAbilityChange struct {
+	Level   int
+	Ability Ability
+	Added   bool
+}
+
+/* How a card changed between two catalogs */
+type CardDiff struct {
+	CardDetailID   int
+	Name           string
+	IsAdded        bool
+	IsRemoved      bool
+	StatChanges    []CardStatChange
+	AbilityChanges []CardAbilityChange
+}
+
+func (d CardDiff) HasManaChange() bool {
+	for _, c := range d.StatChanges {
+		if c.Stat == "mana" {
+			return true
+		}
+	}
+	return false
+}
+
+func (d CardDiff) HasChanges() bool {
+	return d.IsAdded || d.IsRemoved || len(d.StatChanges) > 0 || len(d.AbilityChanges) > 0
+}
+
+/* Every card that was added, removed or changed between the catalogs, sorted by card detail id. Cards that can't be decoded are reported and left out. */
+func DiffCardCatalogs(before, after CardDetailMap) ([]CardDiff, []error) {
+	ids := make([]int, 0)
+	for id := range before {
+		ids = append(ids, id)
+	}
+	for id := range after {
+		if _, ok := before[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	diffs := make([]CardDiff, 0)
+	errs := make([]error, 0)
+	for _, id := range ids {
+		beforeCard, inBefore := before[id]
+		afterCard, inAfter := after[id]
+		var diff CardDiff
+		switch {
+		case !inBefore:
+			diff = CardDiff{CardDetailID: id, Name: afterCard.Name, IsAdded: true}
+		case !inAfter:
+			diff = CardDiff{CardDetailID: id, Name: beforeCard.Name, IsRemoved: true}
+		default:
+			var err error
+			diff, err = DiffCards(beforeCard, afterCard)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+		if diff.HasChanges() {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs, errs
+}
+
+/* Stats per level and abilities per level that changed between two versions of a card. Error if either version can't be decoded. */
+func DiffCards(before, after CardDetail) (CardDiff, error) {
+	diff := CardDiff{CardDetailID: after.ID, Name: after.Name, StatChanges: make([]CardStatChange, 0), AbilityChanges: make([]CardAbilityChange, 0)}
+	beforeStats, err := DecodeCardStats(before)
+	if err != nil {
+		return diff, err
+	}
+	afterStats, err := DecodeCardStats(after)
+	if err != nil {
+		return diff, err
+	}
+
+	for _, name := range []string{"mana", "attack", "ranged", "magic", "armor", "health", "speed"} {
+		if beforeStats.IsSummoner && afterStats.IsSummoner {
+			b := *getFlatStat(&beforeStats.SummonerStats, name)
+			a := *getFlatStat(&afterStats.SummonerStats, name)
+			if b != a {
+				diff.StatChanges = append(diff.StatChanges, CardStatChange{Stat: name, Before: b, After: a})
+			}
+			continue
+		}
+		b := getStatsOfLevels(beforeStats, name)
+		a := getStatsOfLevels(afterStats, name)
+		for level := 1; level <= utils.GetBigger(len(b), len(a)); level++ {
+			bValue := getLevelValue(b, level)
+			aValue := getLevelValue(a, level)
+			if bValue != aValue {
+				diff.StatChanges = append(diff.StatChanges, CardStatChange{Stat: name, Level: level, Before: bValue, After: aValue})
+			}
+		}
+	}
+
+	for level := 1; level <= utils.GetBigger(len(beforeStats.Abilities), len(afterStats.Abilities)); level++ {
+		b := getAbilitiesUnlockedAt(beforeStats, level)
+		a := getAbilitiesUnlockedAt(afterStats, level)
+		for _, ability := range b {
+			if !utils.Contains(a, ability) {
+				diff.AbilityChanges = append(diff.AbilityChanges, CardAbilityChange{Level: level, Ability: ability, Added: false})
+			}
+		}
+		for _, ability := range a {
+			if !utils.Contains(b, ability) {
+				diff.AbilityChanges = append(diff.AbilityChanges, CardAbilityChange{Level: level, Ability: ability, Added: true})
+			}
+		}
+	}
+	return diff, nil
+}
+
+/* Stat per level. The flat stat of a summoner counts as its level 1 */
+func getStatsOfLevels(decoded DecodedCardStats, name string) []int {
+	if decoded.IsSummoner {
+		return []int{*getFlatStat(&decoded.SummonerStats, name)}
+	}
+	return *getStatByLevel(&decoded.MonsterStats, name)
+}
+
+func getLevelValue(stats []int, level int) int {
+	if level > len(stats) {
+		return 0
+	}
+	return stats[level-1]
+}
+
+func getAbilitiesUnlockedAt(decoded DecodedCardStats, level int) []Ability {
+	if level > len(decoded.Abilities) {
+		return []Ability{}
+	}
+	return decoded.Abilities[level-1]
+}
@@ -19,6 +19,8 @@ const (
 	COLOR_WHITE           = "White"
 )
 
+var CARD_COLORS = []CardColor{COLOR_BLACK, COLOR_BLUE, COLOR_GOLD, COLOR_GRAY, COLOR_GREEN, COLOR_RED, COLOR_WHITE}
+
 type Ability string
 
 const (
@@ -103,6 +105,11 @@ const (
 	DICE
 	GLADIUS
 	CHAOS
+	RIFTWATCHERS     CardEdition = 8
+	SOULBOUND        CardEdition = 10
+	REBELLION        CardEdition = 12
+	REBELLION_REWARD CardEdition = 13
+	CONCLAVE_ARCANA  CardEdition = 14
 )
 
 type CardAttackType int
@@ -130,6 +137,9 @@ const (
 	TEAM_NUM_TIE
 )
 
+/* Position of the summoner where a team position is expected */
+const SUMMONER_POSITION = -1
+
 type AdditionalBattleAction string
 
 const (
@@ -176,42 +186,62 @@ func (a AdditionalBattleAction) String() string {
 	return string(a)
 }
 
+/** Battle action of a normal attack of the attack type */
+func GetAttackBattleAction(attackType CardAttackType) AdditionalBattleAction {
+	if attackType == ATTACK_TYPE_MAGIC {
+		return BATTLE_ACTION_MAGIC
+	}
+	if attackType == ATTACK_TYPE_RANGED {
+		return BATTLE_ACTION_RANGED
+	}
+	if attackType == ATTACK_TYPE_MELEE {
+		return BATTLE_ACTION_MELEE
+	}
+	return BATTLE_ACTION_ATTACK
+}
+
 type Ruleset string
 
 const (
-	RULESET_AIM_TRUE              Ruleset = "Aim True"
-	RULESET_ARMORED_UP                    = "Armored Up"
-	RULESET_BACK_TO_BASICS                = "Back to Basics"
-	RULESET_BROKEN_ARROWS                 = "Broken Arrows"
-	RULESET_CLOSE_RANGE                   = "Close Range"
-	RULESET_EARTHQUAKE                    = "Earthquake"
-	RULESET_EQUAL_OPPORTUNITY             = "Equal Opportunity"
-	RULESET_EQUALIZER                     = "Equalizer"
-	RULESET_EVEN_STEVENS                  = "Even Stevens"
-	RULESET_EXPLOSIVE_WEAPONRY            = "Explosive Weaponry"
-	RULESET_FOG_OF_WAR                    = "Fog of War"
-	RULESET_HEALED_OUT                    = "Healed Out"
-	RULESET_HEAVY_HITTERS                 = "Heavy Hitters"
-	RULESET_HOLY_PROTECTION               = "Holy Protection"
-	RULESET_KEEP_YOUR_DISTANCE            = "Keep Your Distance"
-	RULESET_LITTLE_LEAGUE                 = "Little League"
-	RULESET_LOST_LEGENDARIES              = "Lost Legendaries"
-	RULESET_LOST_MAGIC                    = "Lost Magic"
-	RULESET_MELEE_MAYHEM                  = "Melee Mayhem"
-	RULESET_NOXIOUS_FUMES                 = "Noxious Fumes"
-	RULESET_ODD_ONES_OUT                  = "Odd Ones Out"
-	RULESET_REVERSE_SPEED                 = "Reverse Speed"
-	RULESET_RISE_OF_THE_COMMONS           = "Rise of the Commons"
-	RULESET_SILENCED_SUMMONERS            = "Silenced Summoners"
-	RULESET_SPREADING_FURY                = "Spreading Fury"
-	RULESET_STAMPEDE                      = "Stampede"
-	RULESET_STANDARD                      = "Standard"
-	RULESET_SUPER_SNEAK                   = "Super Sneak"
-	RULESET_TAKING_SIDES                  = "Taking Sides"
-	RULESET_TARGET_PRACTICE               = "Target Practice"
-	RULESET_UNPROTECTED                   = "Unprotected"
-	RULESET_UP_CLOSE_AND_PERSONAL         = "Up Close & Personal"
-	RULESET_WEAK_MAGIC                    = "Weak Magic"
+	RULESET_AIM_TRUE                Ruleset = "Aim True"
+	RULESET_ARE_YOU_NOT_ENTERTAINED         = "Are You Not Entertained?"
+	RULESET_ARMORED_UP                      = "Armored Up"
+	RULESET_BACK_TO_BASICS                  = "Back to Basics"
+	RULESET_BROKEN_ARROWS                   = "Broken Arrows"
+	RULESET_CLOSE_RANGE                     = "Close Range"
+	RULESET_COUNTERSPELL                    = "Counterspell"
+	RULESET_EARTHQUAKE                      = "Earthquake"
+	RULESET_EQUAL_OPPORTUNITY               = "Equal Opportunity"
+	RULESET_EQUALIZER                       = "Equalizer"
+	RULESET_EVEN_STEVENS                    = "Even Stevens"
+	RULESET_EXPLOSIVE_WEAPONRY              = "Explosive Weaponry"
+	RULESET_FIRE_AND_REGRET                 = "Fire & Regret"
+	RULESET_FOG_OF_WAR                      = "Fog of War"
+	RULESET_GOING_THE_DISTANCE              = "Going the Distance"
+	RULESET_HEALED_OUT                      = "Healed Out"
+	RULESET_HEAVY_HITTERS                   = "Heavy Hitters"
+	RULESET_HOLY_PROTECTION                 = "Holy Protection"
+	RULESET_KEEP_YOUR_DISTANCE              = "Keep Your Distance"
+	RULESET_LITTLE_LEAGUE                   = "Little League"
+	RULESET_LOST_LEGENDARIES                = "Lost Legendaries"
+	RULESET_LOST_MAGIC                      = "Lost Magic"
+	RULESET_MELEE_MAYHEM                    = "Melee Mayhem"
+	RULESET_NOXIOUS_FUMES                   = "Noxious Fumes"
+	RULESET_ODD_ONES_OUT                    = "Odd Ones Out"
+	RULESET_REVERSE_SPEED                   = "Reverse Speed"
+	RULESET_RISE_OF_THE_COMMONS             = "Rise of the Commons"
+	RULESET_SILENCED_SUMMONERS              = "Silenced Summoners"
+	RULESET_SPREADING_FURY                  = "Spreading Fury"
+	RULESET_STAMPEDE                        = "Stampede"
+	RULESET_STANDARD                        = "Standard"
+	RULESET_SUPER_SNEAK                     = "Super Sneak"
+	RULESET_TAKING_SIDES                    = "Taking Sides"
+	RULESET_TARGET_PRACTICE                 = "Target Practice"
+	RULESET_UNPROTECTED                     = "Unprotected"
+	RULESET_UP_CLOSE_AND_PERSONAL           = "Up Close & Personal"
+	RULESET_WANDS_OUT                       = "Wands Out"
+	RULESET_WEAK_MAGIC                      = "Weak Magic"
+	RULESET_WHAT_DOESNT_KILL_YOU            = "What Doesn't Kill You"
 )
 
 type Stat int
@@ -0,0 +1,171 @@
+package game_models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	utils "github.com/YukiUmetsu/go-spl-simulator/game_utils"
+)
+
+/* Abilities the engine implements in the game phases (the ones with a handler are supported too) */
+var ENGINE_ABILITIES = []Ability{
+	ABILITY_AFFLICTION, ABILITY_AMPLIFY, ABILITY_BACKFIRE, ABILITY_BLAST, ABILITY_BLIND, ABILITY_BLOODLUST,
+	ABILITY_CAMOUFLAGE, ABILITY_CLEANSE, ABILITY_CLOSE_RANGE, ABILITY_CRIPPLE, ABILITY_DEATHBLOW, ABILITY_DEMORALIZE,
+	ABILITY_DISPEL, ABILITY_DIVINE_SHIELD, ABILITY_DODGE, ABILITY_DOUBLE_STRIKE, ABILITY_ENRAGE, ABILITY_FLYING,
+	ABILITY_FORCEFIELD, ABILITY_GIANT_KILLER, ABILITY_HALVING, ABILITY_HEADWINDS, ABILITY_HEAL, ABILITY_IMMUNITY,
+	ABILITY_INSPIRE, ABILITY_KNOCK_OUT, ABILITY_LAST_STAND, ABILITY_LIFE_LEECH, ABILITY_OPPORTUNITY, ABILITY_OPPRESS,
+	ABILITY_PHASE, ABILITY_PIERCING, ABILITY_POISON, ABILITY_PROTECT, ABILITY_REACH, ABILITY_RECHARGE,
+	ABILITY_REFLECTION_SHIELD, ABILITY_REPAIR, ABILITY_RESURRECT, ABILITY_RUST, ABILITY_SCATTERSHOT, ABILITY_SHATTER,
+	ABILITY_SHIELD, ABILITY_SILENCE, ABILITY_SLOW, ABILITY_SNARE, ABILITY_SNEAK, ABILITY_SNIPE, ABILITY_STRENGTHEN,
+	ABILITY_STUN, ABILITY_SWIFTNESS, ABILITY_TANK_HEAL, ABILITY_TAUNT, ABILITY_TRAMPLE, ABILITY_TRIAGE,
+	ABILITY_TRUE_STRIKE, ABILITY_VOID, ABILITY_VOID_ARMOR, ABILITY_WEAKEN, ABILITY_MELEE_MAYHEM,
+}
+
+/* Tells if the engine does something with the ability */
+func IsAbilitySupported(ability Ability) bool {
+	return utils.Contains(ENGINE_ABILITIES, ability) || HasAbilityHandler(ability)
+}
+
+/* Tells if the engine does something with the ruleset */
+func IsRulesetSupported(ruleset Ruleset) bool {
+	return HasRulesetHandler(ruleset)
+}
+
+/* Abilities (with the ids of the cards that have them) and rulesets the engine would silently ignore */
+type CoverageReport struct {
+	UnsupportedAbilities map[Ability][]int
+	UnsupportedRulesets  []Ruleset
+}
+
+func NewCoverageReport() CoverageReport {
+	return CoverageReport{UnsupportedAbilities: make(map[Ability][]int), UnsupportedRulesets: make([]Ruleset, 0)}
+}
+
+/* Tells if every ability and ruleset found is supported, i.e. simulation results can be trusted */
+func (r CoverageReport) IsSupported() bool {
+	return len(r.UnsupportedAbilities) == 0 && len(r.UnsupportedRulesets) == 0
+}
+
+/* Unsupported abilities in alphabetical order */
+func (r CoverageReport) GetUnsupportedAbilities() []Ability {
+	abilities := make([]Ability, 0, len(r.UnsupportedAbilities))
+	for a := range r.UnsupportedAbilities {
+		abilities = append(abilities, a)
+	}
+	sort.Slice(abilities, func(i, j int) bool { return abilities[i] < abilities[j] })
+	return abilities
+}
+
+func (r *CoverageReport) AddAbility(ability Ability, cardDetailID int) {
+	if ability == "" || IsAbilitySupported(ability) {
+		return
+	}
+	if r.UnsupportedAbilities == nil {
+		r.UnsupportedAbilities = make(map[Ability][]int)
+	}
+	for _, id := range r.UnsupportedAbilities[ability] {
+		if id == cardDetailID {
+			return
+		}
+	}
+	r.UnsupportedAbilities[ability] = append(r.UnsupportedAbilities[ability], cardDetailID)
+}
+
+func (r *CoverageReport) AddRuleset(ruleset Ruleset) {
+	if ruleset == "" || IsRulesetSupported(ruleset) || RulesetsContains(r.UnsupportedRulesets, ruleset) {
+		return
+	}
+	r.UnsupportedRulesets = append(r.UnsupportedRulesets, ruleset)
+}
+
+/* Checks every ability the card has at any level. Returns the *CardDecodeError of a malformed card, nothing is checked then */
+func (r *CoverageReport) AddCard(cardDetail CardDetail) error {
+	decoded, err := DecodeCardStats(cardDetail)
+	if err != nil {
+		return err
+	}
+	for _, abilities := range decoded.Abilities {
+		for _, a := range abilities {
+			r.AddAbility(a, cardDetail.ID)
+		}
+	}
+	return nil
+}
+
+/* Checks the abilities the summoner and monsters of the team have */
+func (r *CoverageReport) AddTeam(team *GameTeam) {
+	summoner := team.GetSummoner()
+	if summoner != nil {
+		for _, a := range summoner.Abilities {
+			r.AddAbility(a, summoner.GetCardDetail().ID)
+		}
+	}
+	for _, m := range team.GetMonstersList() {
+		for _, a := range m.Abilities {
+			r.AddAbility(a, m.GetCardDetail().ID)
+		}
+	}
+}
+
+func (r *CoverageReport) Merge(other CoverageReport) {
+	for a, ids := range other.UnsupportedAbilities {
+		for _, id := range ids {
+			r.AddAbility(a, id)
+		}
+	}
+	for _, ruleset := range other.UnsupportedRulesets {
+		r.AddRuleset(ruleset)
+	}
+}
+
+func (r CoverageReport) String() string {
+	parts := make([]string, 0)
+	for _, a := range r.GetUnsupportedAbilities() {
+		parts = append(parts, fmt.Sprintf("ability %q (cards %v)", a, r.UnsupportedAbilities[a]))
+	}
+	for _, ruleset := range r.UnsupportedRulesets {
+		parts = append(parts, fmt.Sprintf("ruleset %q", ruleset))
+	}
+	return strings.Join(parts, ", ")
+}
+
+/* Abilities of every card of the catalog the engine doesn't implement. Malformed cards are left out and returned as errors, in card id order */
+func CheckCardCoverage(cardDetailMap CardDetailMap) (CoverageReport, []error) {
+	report := NewCoverageReport()
+	errs := make([]error, 0)
+	ids := make([]int, 0, len(cardDetailMap))
+	for id := range cardDetailMap {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		if err := report.AddCard(cardDetailMap[id]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, ids := range report.UnsupportedAbilities {
+		sort.Ints(ids)
+	}
+	return report, errs
+}
+
+/* Abilities of the cards in the game and rulesets of the game the engine doesn't implement */
+func (g *Game) CheckCoverage() CoverageReport {
+	report := NewCoverageReport()
+	report.AddTeam(g.team1)
+	report.AddTeam(g.team2)
+	for _, ruleset := range g.rulesets {
+		report.AddRuleset(ruleset)
+	}
+	return report
+}
+
+/* The game has abilities or rulesets the engine doesn't implement */
+type UnsupportedMechanicsError struct {
+	Report CoverageReport
+}
+
+func (e *UnsupportedMechanicsError) Error() string {
+	return "unsupported mechanics: " + e.Report.String()
+}
@@ -0,0 +1,156 @@
+package game_models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	utils "github.com/YukiUmetsu/go-spl-simulator/game_utils"
+	"gopkg.in/yaml.v3"
+)
+
+/* Ids from this one on are reserved for custom cards, official cards are far below */
+const CUSTOM_CARD_FIRST_ID = 1000000
+
+/* Edition of custom cards without editions: the newest set, so they are legal in Wild and Modern like a new official card */
+const CUSTOM_CARD_DEFAULT_EDITION = CONCLAVE_ARCANA
+
+/* Card types as in the cards/get_details payload */
+const (
+	CARD_TYPE_MONSTER  CardType = "Monster"
+	CARD_TYPE_SUMMONER CardType = "Summoner"
+)
+
+/*
+A card that is not in the official catalog. Stats have the shape of the cards/get_details payload:
+a value per level for monsters (e.g. health: [3, 4, 4]), a single value for summoners (e.g. health: 0).
+Abilities are a list per level (e.g. [[], ["Shield"]]). Editions are comma separated (e.g. "12,14"), CUSTOM_CARD_DEFAULT_EDITION if empty.
+*/
+type CustomCardDefinition struct {
+	// 0: the next free reserved id
+	ID       int          `json:"id" yaml:"id"`
+	Name     string       `json:"name" yaml:"name"`
+	Color    CardColor    `json:"color" yaml:"color"`
+	Type     CardType     `json:"type" yaml:"type"`
+	Rarity   int          `json:"rarity" yaml:"rarity"`
+	Editions string       `json:"editions" yaml:"editions"`
+	Stats    CardRawStats `json:"stats" yaml:"stats"`
+}
+
+func IsCustomCard(cardDetailID int) bool {
+	return cardDetailID >= CUSTOM_CARD_FIRST_ID
+}
+
+/* Parses a JSON or YAML (.yaml, .yml) list of custom cards */
+func LoadCustomCards(path string) ([]CustomCardDefinition, error) {
+	definitions := make([]CustomCardDefinition, 0)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return definitions, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &definitions)
+	default:
+		err = json.Unmarshal(data, &definitions)
+	}
+	return definitions, err
+}
+
+/* Card detail of the definition, with the stats validated like the official cards */
+func (d CustomCardDefinition) ToCardDetail(id int) (CardDetail, error) {
+	cardDetail := CardDetail{
+		ID:       id,
+		Name:     d.Name,
+		Color:    d.Color,
+		Type:     d.Type,
+		Rarity:   d.Rarity,
+		Editions: d.Editions,
+		Stats:    d.Stats,
+	}
+	definitionErr := func(field, reason string) error {
+		return &CardDecodeError{CardDetailID: id, Name: d.Name, Field: field, Reason: reason}
+	}
+
+	if d.Name == "" {
+		return cardDetail, definitionErr("name", "missing")
+	}
+	if !utils.Contains(CARD_COLORS, d.Color) {
+		return cardDetail, definitionErr("color", fmt.Sprintf("%q is not one of %v", d.Color, CARD_COLORS))
+	}
+	if d.Rarity < 1 || d.Rarity > 4 {
+		return cardDetail, definitionErr("rarity", fmt.Sprintf("%d out of 1-4", d.Rarity))
+	}
+	editions, err := ParseEditions(d.Editions)
+	if err != nil {
+		return cardDetail, definitionErr("editions", err.Error())
+	}
+	if len(editions) == 0 {
+		cardDetail.Editions = strconv.Itoa(int(CUSTOM_CARD_DEFAULT_EDITION))
+	}
+	decoded, err := DecodeCardStats(cardDetail)
+	if err != nil {
+		return cardDetail, err
+	}
+	switch {
+	case d.Type == CARD_TYPE_SUMMONER && !decoded.IsSummoner:
+		return cardDetail, definitionErr("stats", "a summoner has a single value per stat")
+	case d.Type == CARD_TYPE_MONSTER && decoded.IsSummoner:
+		return cardDetail, definitionErr("stats", "a monster has a value per level")
+	case d.Type != CARD_TYPE_SUMMONER && d.Type != CARD_TYPE_MONSTER:
+		return cardDetail, definitionErr("type", fmt.Sprintf("%q is not Monster or Summoner", d.Type))
+	}
+	return cardDetail, nil
+}
+
+/*
+Returns a copy of the catalog with the custom cards added, and an error for each card that couldn't be added.
+Cards without an id get the next free reserved id. Names must not be taken by another card.
+*/
+func MergeCustomCards(cardDetailMap CardDetailMap, definitions []CustomCardDefinition) (CardDetailMap, []error) {
+	merged := make(CardDetailMap, len(cardDetailMap)+len(definitions))
+	names := make(map[string]int)
+	nextID := CUSTOM_CARD_FIRST_ID
+	for id, cd := range cardDetailMap {
+		merged[id] = cd
+		names[cd.Name] = id
+		if id >= nextID {
+			nextID = id + 1
+		}
+	}
+
+	errs := make([]error, 0)
+	for _, d := range definitions {
+		id := d.ID
+		if id == 0 {
+			id = nextID
+		}
+		if !IsCustomCard(id) {
+			errs = append(errs, &CardDecodeError{CardDetailID: id, Name: d.Name, Field: "id", Reason: fmt.Sprintf("custom card ids start at %d", CUSTOM_CARD_FIRST_ID)})
+			continue
+		}
+		if _, ok := merged[id]; ok {
+			errs = append(errs, &CardDecodeError{CardDetailID: id, Name: d.Name, Field: "id", Reason: "already taken"})
+			continue
+		}
+		if takenBy, ok := names[d.Name]; ok {
+			errs = append(errs, &CardDecodeError{CardDetailID: id, Name: d.Name, Field: "name", Reason: fmt.Sprintf("already taken by card %d", takenBy)})
+			continue
+		}
+		cardDetail, err := d.ToCardDetail(id)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		merged[id] = cardDetail
+		names[d.Name] = id
+		if id >= nextID {
+			nextID = id + 1
+		}
+	}
+	return merged, errs
+}
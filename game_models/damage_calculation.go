@@ -0,0 +1,115 @@
+package game_models
+
+import (
+	"fmt"
+	"strings"
+)
+
+/** A step that lowered the incoming damage of a hit (e.g. Forcefield, Void, Shield) */
+type DamageReduction struct {
+	Ability Ability
+	Before  int
+	After   int
+}
+
+/** Something that happened because of the hit (e.g. Life Leech heal, Thorns damage to the attacker) */
+type DamageSideEffect struct {
+	Ability Ability
+	Card    GameCardInterface
+	Amount  int
+}
+
+/*
+Record of how the damage of a single hit was calculated.
+The attack side is filled by the game (stats, summoner, buffs, multipliers),
+the defense side by HitMonsterWithMagic / HitMonsterWithPhysical.
+Only built when the game logs or has event listeners, nil otherwise. The methods accept a nil calculation.
+*/
+type DamageCalculation struct {
+	Round      int
+	Source     AdditionalBattleAction
+	AttackType CardAttackType
+	Attacker   GameCardInterface
+	Target     GameCardInterface
+
+	// attack side
+	BaseAttack          int       // attack stat of the card itself
+	SummonerBonus       int       // attack added (or removed) by the summoners
+	ModifierBonus       int       // attack added (or removed) by buffs, debuffs, Last Stand and Enrage
+	Modifiers           []Ability // buffs, debuffs and abilities that changed the attack
+	PostAbilityAttack   int
+	Multiplier          int
+	MultiplierAbilities []Ability
+
+	// defense side
+	IncomingDamage       int
+	Reductions           []DamageReduction
+	DivineShieldAbsorbed bool
+	ArmorDamage          int
+	HealthDamage         int
+	Remainder            int
+	PiercingDamage       int
+
+	SideEffects []DamageSideEffect
+}
+
+func (d *DamageCalculation) AddReduction(ability Ability, before, after int) {
+	if d == nil {
+		return
+	}
+	d.Reductions = append(d.Reductions, DamageReduction{Ability: ability, Before: before, After: after})
+}
+
+func (d *DamageCalculation) AddSideEffect(ability Ability, card GameCardInterface, amount int) {
+	if d == nil {
+		return
+	}
+	d.SideEffects = append(d.SideEffects, DamageSideEffect{Ability: ability, Card: card, Amount: amount})
+}
+
+/** Damage that actually landed on the target (armor + health + piercing) */
+func (d *DamageCalculation) GetTotalDamage() int {
+	if d == nil {
+		return 0
+	}
+	return d.ArmorDamage + d.HealthDamage + d.PiercingDamage
+}
+
+func (d *DamageCalculation) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "[Round %d] %s", d.Round+1, d.Source)
+	if d.Attacker != nil {
+		fmt.Fprintf(&sb, " by %s", d.Attacker.GetName())
+	}
+	if d.Target != nil {
+		fmt.Fprintf(&sb, " on %s", d.Target.GetName())
+	}
+	if d.PostAbilityAttack > 0 {
+		fmt.Fprintf(&sb, ": base %d, summoner %+d, modifiers %+d %v => %d", d.BaseAttack, d.SummonerBonus, d.ModifierBonus, d.Modifiers, d.PostAbilityAttack)
+	}
+	if d.Multiplier > 1 {
+		fmt.Fprintf(&sb, ", x%d %v", d.Multiplier, d.MultiplierAbilities)
+	}
+	fmt.Fprintf(&sb, ", incoming %d", d.IncomingDamage)
+	for _, r := range d.Reductions {
+		fmt.Fprintf(&sb, ", %s %d->%d", r.Ability, r.Before, r.After)
+	}
+	if d.DivineShieldAbsorbed {
+		sb.WriteString(", absorbed by Divine Shield")
+	}
+	fmt.Fprintf(&sb, ", armor -%d, health -%d", d.ArmorDamage, d.HealthDamage)
+	if d.PiercingDamage > 0 {
+		fmt.Fprintf(&sb, ", piercing -%d", d.PiercingDamage)
+	}
+	if d.Remainder > 0 {
+		fmt.Fprintf(&sb, ", remainder %d", d.Remainder)
+	}
+	for _, s := range d.SideEffects {
+		cardName := ""
+		if s.Card != nil {
+			cardName = s.Card.GetName()
+		}
+		fmt.Fprintf(&sb, ", %s %s %d", s.Ability, cardName, s.Amount)
+	}
+	return sb.String()
+}
@@ -7,65 +7,79 @@ import (
 // TODO: Should this return the reduced damage or normal? Life steal against void?
 /** Hits the monster with magic damage. Returns the remainder damage. */
 func HitMonsterWithMagic(game *Game, target *MonsterCard, magicDamage int) BattleDamage {
+	calc := game.newDamageCalculation(target, ATTACK_TYPE_MAGIC, magicDamage)
+
 	// consider forcefield
-	if target.HasAbility(ABILITY_FORCEFIELD) && magicDamage >= FORCEFIELD_MIN_DAMAGE {
+	if target.HasAbility(ABILITY_FORCEFIELD) && magicDamage >= game.GetConfig().ForcefieldMinDamage {
+		calc.AddReduction(ABILITY_FORCEFIELD, magicDamage, 1)
 		magicDamage = 1
 	}
 
 	// consider Divine shield
-	if target.HasAbility(ABILITY_DIVINE_SHIELD) {
-		target.RemoveDivineShield()
-		game.CreateAndAddBattleLog(BATTLE_ACTION_REMOVE_DIVINE_SHIELD, target, nil, 0)
+	if game.doAbilitiesBeforeBeingHit(&AbilityHitContext{Target: target, AttackType: ATTACK_TYPE_MAGIC}) {
+		if calc != nil {
+			calc.DivineShieldAbsorbed = true
+		}
 		return BattleDamage{
 			Attack:           1,
 			DamageDone:       0,
 			Remainder:        0,
 			ActualDamageDone: 0,
+			Calculation:      calc,
 		}
 	}
 
 	if magicDamage < 1 {
-		return BattleDamage{}
+		return BattleDamage{Calculation: calc}
 	}
 
 	// hit void
 	if target.HasAbility(ABILITY_VOID) {
 		if magicDamage == 1 {
-			return BattleDamage{Attack: 1, Remainder: 1}
+			calc.AddReduction(ABILITY_VOID, 1, 0)
+			if calc != nil {
+				calc.Remainder = 1
+			}
+			return BattleDamage{Attack: 1, Remainder: 1, Calculation: calc}
 		}
-		magicDamage = int(math.Floor(float64(magicDamage+1) / 2))
+		reducedDamage := int(math.Floor(float64(magicDamage+1) / 2))
+		calc.AddReduction(ABILITY_VOID, magicDamage, reducedDamage)
+		magicDamage = reducedDamage
 	}
 
 	// hit void armor
 	if target.HasAbility(ABILITY_VOID_ARMOR) {
 		if target.Armor > 0 {
-			remainder := HitArmor(target, magicDamage)
+			remainder := hitArmorWithCalculation(calc, target, magicDamage)
 			return BattleDamage{
 				Attack:           1,
 				DamageDone:       0,
 				Remainder:        remainder,
 				ActualDamageDone: 0,
+				Calculation:      calc,
 			}
 
 		}
 
 		// has void armor ability but no armor left
-		remainder := HitHealth(target, magicDamage)
+		remainder := hitHealthWithCalculation(calc, target, magicDamage)
 		return BattleDamage{
 			Attack:           magicDamage,
 			DamageDone:       magicDamage,
 			Remainder:        remainder,
 			ActualDamageDone: magicDamage - remainder,
+			Calculation:      calc,
 		}
 	}
 
 	// no void armor
-	remainder := HitHealth(target, magicDamage)
+	remainder := hitHealthWithCalculation(calc, target, magicDamage)
 	return BattleDamage{
 		Attack:           magicDamage,
 		DamageDone:       magicDamage,
 		Remainder:        remainder,
 		ActualDamageDone: magicDamage - remainder,
+		Calculation:      calc,
 	}
 }
 
@@ -77,49 +91,82 @@ func HitMonsterWithPhysical(game *Game, target *MonsterCard, damageAmount int) B
 	if target == nil {
 		return BattleDamage{}
 	}
+	calc := game.newDamageCalculation(target, ATTACK_TYPE_MELEE, damageAmount)
 
 	// consider forcefield
-	if target.HasAbility(ABILITY_FORCEFIELD) && damageAmount >= FORCEFIELD_MIN_DAMAGE {
+	if target.HasAbility(ABILITY_FORCEFIELD) && damageAmount >= game.GetConfig().ForcefieldMinDamage {
+		calc.AddReduction(ABILITY_FORCEFIELD, damageAmount, 1)
 		damageAmount = 1
 	}
 
 	// For things like thorns, this returns 1 to show a successful attack.
-	if target.HasAbility(ABILITY_DIVINE_SHIELD) {
-		target.RemoveDivineShield()
-		game.CreateAndAddBattleLog(BATTLE_ACTION_REMOVE_DIVINE_SHIELD, target, nil, 0)
-		return BattleDamage{Attack: 1}
+	if game.doAbilitiesBeforeBeingHit(&AbilityHitContext{Target: target, AttackType: ATTACK_TYPE_MELEE}) {
+		if calc != nil {
+			calc.DivineShieldAbsorbed = true
+		}
+		return BattleDamage{Attack: 1, Calculation: calc}
 	}
 
 	if damageAmount < 1 {
-		return BattleDamage{}
+		return BattleDamage{Calculation: calc}
 	}
 
 	// consider hitting shield
 	if target.HasAbility(ABILITY_SHIELD) {
 		if damageAmount == 1 {
-			return BattleDamage{Attack: 1, Remainder: 1}
+			calc.AddReduction(ABILITY_SHIELD, 1, 0)
+			if calc != nil {
+				calc.Remainder = 1
+			}
+			return BattleDamage{Attack: 1, Remainder: 1, Calculation: calc}
 		}
-		damageAmount = int(math.Floor(float64(damageAmount+1) / 2))
+		reducedDamage := int(math.Floor(float64(damageAmount+1) / 2))
+		calc.AddReduction(ABILITY_SHIELD, damageAmount, reducedDamage)
+		damageAmount = reducedDamage
 	}
 
 	if target.Armor > 0 {
-		remainder := HitArmor(target, damageAmount)
+		remainder := hitArmorWithCalculation(calc, target, damageAmount)
 		return BattleDamage{
 			Attack:           damageAmount,
 			DamageDone:       damageAmount,
 			Remainder:        remainder,
 			ActualDamageDone: damageAmount - remainder,
+			Calculation:      calc,
 		}
 	}
 
 	// normal attack hitting health
-	remainderDamage := HitHealth(target, damageAmount)
+	remainderDamage := hitHealthWithCalculation(calc, target, damageAmount)
 	return BattleDamage{
 		Attack:           damageAmount,
 		DamageDone:       damageAmount,
 		Remainder:        remainderDamage,
 		ActualDamageDone: damageAmount - remainderDamage,
+		Calculation:      calc,
+	}
+}
+
+/** HitArmor that also records the armor damage in the calculation (if any) */
+func hitArmorWithCalculation(calc *DamageCalculation, target *MonsterCard, damageAmount int) int {
+	previousArmor := target.Armor
+	remainder := HitArmor(target, damageAmount)
+	if calc != nil {
+		calc.ArmorDamage = previousArmor - target.Armor
+		calc.Remainder = remainder
+	}
+	return remainder
+}
+
+/** HitHealth that also records the health damage in the calculation (if any) */
+func hitHealthWithCalculation(calc *DamageCalculation, target *MonsterCard, damageAmount int) int {
+	previousHealth := target.Health
+	remainder := HitHealth(target, damageAmount)
+	if calc != nil {
+		calc.HealthDamage = previousHealth - target.Health
+		calc.Remainder = remainder
 	}
+	return remainder
 }
 
 /** Returns remainder damage after hitting armor. */
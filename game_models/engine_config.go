@@ -0,0 +1,84 @@
+package game_models
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+/* Balance parameters of the engine. Defaults to the current game values, e.g. change them to simulate a balance patch. */
+type EngineConfig struct {
+	DodgeChance          float64 `json:"dodge_chance" yaml:"dodge_chance"`
+	FlyingDodgeChance    float64 `json:"flying_dodge_chance" yaml:"flying_dodge_chance"`
+	BlindDodgeChance     float64 `json:"blind_dodge_chance" yaml:"blind_dodge_chance"`
+	SpeedDiffDodgeChance float64 `json:"speed_diff_dodge_chance" yaml:"speed_diff_dodge_chance"`
+	AfflictionChance     float64 `json:"affliction_chance" yaml:"affliction_chance"`
+	RetaliateChance      float64 `json:"retaliate_chance" yaml:"retaliate_chance"`
+	StunChance           float64 `json:"stun_chance" yaml:"stun_chance"`
+	PoisonChance         float64 `json:"poison_chance" yaml:"poison_chance"`
+
+	BlastMultiplier      float64 `json:"blast_multiplier" yaml:"blast_multiplier"`
+	TankHealMultiplier   float64 `json:"tank_heal_multiplier" yaml:"tank_heal_multiplier"`
+	TriageHealMultiplier float64 `json:"triage_heal_multiplier" yaml:"triage_heal_multiplier"`
+	ForcefieldMinDamage  int     `json:"forcefield_min_damage" yaml:"forcefield_min_damage"`
+	EarthquakeDamage     int     `json:"earthquake_damage" yaml:"earthquake_damage"`
+	PoisonDamage         int     `json:"poison_damage" yaml:"poison_damage"`
+	ThornsDamage         int     `json:"thorns_damage" yaml:"thorns_damage"`
+	RedemptionDamage     int     `json:"redemption_damage" yaml:"redemption_damage"`
+	BackfireDamage       int     `json:"backfire_damage" yaml:"backfire_damage"`
+
+	FatigueRoundNumber int `json:"fatigue_round_number" yaml:"fatigue_round_number"`
+}
+
+func DefaultEngineConfig() EngineConfig {
+	return EngineConfig{
+		DodgeChance:          DODGE_CHANCE,
+		FlyingDodgeChance:    FLYING_DODGE_CHANCE,
+		BlindDodgeChance:     BLIND_DODGE_CHANCE,
+		SpeedDiffDodgeChance: SPEED_DIFF_DODGE_CHANCE,
+		AfflictionChance:     AFFLICTION_CHANCE,
+		RetaliateChance:      RETALIATE_CHANCE,
+		StunChance:           STUN_CHANCE,
+		PoisonChance:         POISON_CHANCE,
+
+		BlastMultiplier:      BLAST_MULTIPLIER,
+		TankHealMultiplier:   TANK_HEAL_MULTIPLIER,
+		TriageHealMultiplier: TRIAGE_HEAL_MULTIPLIER,
+		ForcefieldMinDamage:  FORCEFIELD_MIN_DAMAGE,
+		EarthquakeDamage:     EARTHQUAKE_DAMAGE,
+		PoisonDamage:         POISON_DAMAGE,
+		ThornsDamage:         THORNS_DAMAGE,
+		RedemptionDamage:     REDEMPTION_DAMAGE,
+		BackfireDamage:       BACKFIRE_DAMAGE,
+
+		FatigueRoundNumber: FATIGUE_ROUND_NUMBER,
+	}
+}
+
+/* Parses a JSON or YAML (.yaml, .yml) config. Parameters missing from the file keep their default value. */
+func LoadEngineConfig(path string) (EngineConfig, error) {
+	config := DefaultEngineConfig()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &config)
+	default:
+		err = json.Unmarshal(data, &config)
+	}
+	return config, err
+}
+
+/* Config of the game, the default config if the game was created without one */
+func (g *Game) GetConfig() EngineConfig {
+	if g == nil || g.config == nil {
+		return DefaultEngineConfig()
+	}
+	return *g.config
+}
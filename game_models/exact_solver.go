@@ -0,0 +1,266 @@
+package game_models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// games replayed by the exact solver before it falls back to sampling
+	EXACT_SOLVER_DEFAULT_BUDGET = 20000
+	// games sampled when the budget is exceeded
+	EXACT_SOLVER_DEFAULT_FALLBACK_ITERATIONS = 1000
+)
+
+/* Probability of each result of a battle */
+type OutcomeProbabilities struct {
+	Team1Win float64
+	Team2Win float64
+	Tie      float64
+}
+
+func (o OutcomeProbabilities) GetWinProbability(team TeamNumber) float64 {
+	switch team {
+	case TEAM_NUM_ONE:
+		return o.Team1Win
+	case TEAM_NUM_TWO:
+		return o.Team2Win
+	case TEAM_NUM_TIE:
+		return o.Tie
+	}
+	return 0
+}
+
+func (o OutcomeProbabilities) add(other OutcomeProbabilities, p float64) OutcomeProbabilities {
+	return OutcomeProbabilities{Team1Win: o.Team1Win + other.Team1Win*p, Team2Win: o.Team2Win + other.Team2Win*p, Tie: o.Tie + other.Tie*p}
+}
+
+func getOutcomeOfWinner(winner TeamNumber) OutcomeProbabilities {
+	switch winner {
+	case TEAM_NUM_ONE:
+		return OutcomeProbabilities{Team1Win: 1}
+	case TEAM_NUM_TWO:
+		return OutcomeProbabilities{Team2Win: 1}
+	}
+	return OutcomeProbabilities{Tie: 1}
+}
+
+type ExactSolverResult struct {
+	Probabilities OutcomeProbabilities
+	// false if the budget was exceeded and the probabilities were sampled
+	IsExact bool
+	// games replayed by the solver
+	Nodes int
+	// rounds reached in a state that was already solved
+	MemoHits int
+	// games played by the sampling, 0 if exact
+	SampledGames int
+}
+
+/*
+Computes the exact probability of each result of a battle by branching on every random draw of the game
+with its probability. The game is replayed from the start for each branch with the draws of the branch,
+and the results of identical game states at the start of a round are reused.
+When more than Budget games would be replayed, FallbackIterations games are sampled instead.
+Budget and FallbackIterations of 0 or less are the EXACT_SOLVER_DEFAULT_* values.
+*/
+type ExactSolver struct {
+	createGame         func() *Game
+	Budget             int
+	FallbackIterations int
+	memo               map[string]OutcomeProbabilities
+	nodes              int
+	memoHits           int
+}
+
+/* createGame must return a new game of the battle each time */
+func NewExactSolver(createGame func() *Game) *ExactSolver {
+	return &ExactSolver{createGame: createGame, Budget: EXACT_SOLVER_DEFAULT_BUDGET, FallbackIterations: EXACT_SOLVER_DEFAULT_FALLBACK_ITERATIONS}
+}
+
+func (s *ExactSolver) Solve() ExactSolverResult {
+	if s.Budget <= 0 {
+		s.Budget = EXACT_SOLVER_DEFAULT_BUDGET
+	}
+	if s.FallbackIterations <= 0 {
+		s.FallbackIterations = EXACT_SOLVER_DEFAULT_FALLBACK_ITERATIONS
+	}
+	s.memo = make(map[string]OutcomeProbabilities)
+	s.nodes = 0
+	s.memoHits = 0
+	probabilities, ok := s.solve([]int{})
+	if ok {
+		return ExactSolverResult{Probabilities: probabilities, IsExact: true, Nodes: s.nodes, MemoHits: s.memoHits}
+	}
+
+	sampled := OutcomeProbabilities{}
+	for i := 0; i < s.FallbackIterations; i++ {
+		game := s.createGame()
+		game.PlayGame()
+		sampled = sampled.add(getOutcomeOfWinner(game.GetWinner()), 1/float64(s.FallbackIterations))
+	}
+	return ExactSolverResult{Probabilities: sampled, IsExact: false, Nodes: s.nodes, MemoHits: s.memoHits, SampledGames: s.FallbackIterations}
+}
+
+/* Probabilities of the battle after the draws of the path. false if the budget is exceeded. */
+func (s *ExactSolver) solve(path []int) (OutcomeProbabilities, bool) {
+	if s.nodes >= s.Budget {
+		return OutcomeProbabilities{}, false
+	}
+	s.nodes++
+
+	game := s.createGame()
+	source := &scriptedRandomSource{path: path}
+	game.SetRandomSource(source)
+	checkpoints := &solverCheckpointListener{game: game, source: source, memo: s.memo}
+	game.AddEventListener(checkpoints)
+	playUntilStopped(game)
+
+	var probabilities OutcomeProbabilities
+	switch {
+	case checkpoints.hasMemoHit:
+		s.memoHits++
+		probabilities = checkpoints.memoHit
+	case source.branch != nil:
+		for outcome, p := range source.branch {
+			branchPath := append(append(make([]int, 0, len(path)+1), path...), outcome)
+			branchProbabilities, ok := s.solve(branchPath)
+			if !ok {
+				return OutcomeProbabilities{}, false
+			}
+			probabilities = probabilities.add(branchProbabilities, p)
+		}
+	default:
+		probabilities = getOutcomeOfWinner(game.GetWinner())
+	}
+
+	// nothing is drawn between the end of the path and these rounds
+	for _, key := range checkpoints.keys {
+		s.memo[key] = probabilities
+	}
+	return probabilities, true
+}
+
+/* Stops the game being replayed */
+type stopReplay struct{}
+
+func playUntilStopped(game *Game) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(stopReplay); !ok {
+				panic(r)
+			}
+		}
+	}()
+	game.PlayGame()
+}
+
+/* Replays the draws of the path, then stops the game at the next draw and keeps its outcome probabilities */
+type scriptedRandomSource struct {
+	path   []int
+	index  int
+	branch []float64
+}
+
+func (s *scriptedRandomSource) Chance(label string, p float64) bool {
+	return s.draw([]float64{p, 1 - p}) == 0
+}
+
+func (s *scriptedRandomSource) Pick(label string, n int) int {
+	probabilities := make([]float64, n)
+	for i := range probabilities {
+		probabilities[i] = 1 / float64(n)
+	}
+	return s.draw(probabilities)
+}
+
+func (s *scriptedRandomSource) draw(probabilities []float64) int {
+	if s.index < len(s.path) {
+		outcome := s.path[s.index]
+		s.index++
+		return outcome
+	}
+	s.branch = probabilities
+	panic(stopReplay{})
+}
+
+func (s *scriptedRandomSource) isPathDone() bool {
+	return s.index == len(s.path)
+}
+
+/* Keeps the state of every round started after the path, and stops the game at a state that is already solved */
+type solverCheckpointListener struct {
+	game       *Game
+	source     *scriptedRandomSource
+	memo       map[string]OutcomeProbabilities
+	keys       []string
+	hasMemoHit bool
+	memoHit    OutcomeProbabilities
+}
+
+func (l *solverCheckpointListener) OnBattleEvent(event BattleEvent) {
+	if event.Type != BATTLE_EVENT_ROUND_START || !l.source.isPathDone() {
+		return
+	}
+	key := l.game.GetStateKey()
+	if probabilities, ok := l.memo[key]; ok {
+		l.hasMemoHit = true
+		l.memoHit = probabilities
+		panic(stopReplay{})
+	}
+	l.keys = append(l.keys, key)
+}
+
+/*
+Everything that decides how the rest of the game is played, at the start of a round.
+Games with the same key play out the same way given the same draws.
+*/
+func (g *Game) GetStateKey() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "round %d|", g.roundNumber)
+	for _, team := range []*GameTeam{g.team1, g.team2} {
+		s := team.GetSummoner()
+		fmt.Fprintf(&b, "summoner %d %d %s|", s.cardDetail.ID, s.CardLevel, getGameCardStateKey(s.GameCard))
+		for _, m := range team.GetMonstersList() {
+			fmt.Fprintf(&b, "monster %d %d %s %d %t %t %d %d %d %d %d %t|",
+				m.cardDetail.ID, m.CardLevel,
+				getGameCardStateKey(GameCard{
+					Team: m.Team, DebuffMap: m.DebuffMap, BuffMap: m.BuffMap, Abilities: m.Abilities,
+					Speed: m.Speed, StartingArmor: m.StartingArmor, Armor: m.Armor, Health: m.Health, StartingHealth: m.StartingHealth,
+					Magic: m.Magic, Melee: m.Melee, Ranged: m.Ranged, Mana: m.Mana,
+				}),
+				m.cardPosition, m.isOnlyMonster, m.hasTurnPassed,
+				m.summonerSpeed, m.summonerArmor, m.summonerMelee, m.summonerRanged, m.summonerMagic, m.hadDivineShield)
+		}
+	}
+
+	stunKeys := make([]string, 0, len(g.stunData))
+	for key := range g.stunData {
+		stunKeys = append(stunKeys, key)
+	}
+	sort.Strings(stunKeys)
+	for _, key := range stunKeys {
+		fmt.Fprintf(&b, "stun %s:", key)
+		for _, m := range g.stunData[key] {
+			fmt.Fprintf(&b, " %d-%d", m.Team, m.cardPosition)
+		}
+		b.WriteString("|")
+	}
+	return b.String()
+}
+
+func getGameCardStateKey(c GameCard) string {
+	return fmt.Sprintf("%d %v %v %v %d %d %d %d %d %d %d %d %d",
+		c.Team, getAbilityMapStateKey(c.DebuffMap), getAbilityMapStateKey(c.BuffMap), c.Abilities,
+		c.Speed, c.StartingArmor, c.Armor, c.Health, c.StartingHealth, c.Magic, c.Melee, c.Ranged, c.Mana)
+}
+
+func getAbilityMapStateKey(abilities map[Ability]int) string {
+	keys := make([]string, 0, len(abilities))
+	for ability, count := range abilities {
+		keys = append(keys, fmt.Sprintf("%s=%d", ability, count))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
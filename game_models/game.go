@@ -20,9 +20,27 @@ type Game struct {
 	deadMonsters []*MonsterCard
 	roundNumber  int
 	stunData     map[string][]*MonsterCard // key: "[team number]-[monster name]" e.g. "1-Magnor"
+	/* how the damage of each hit was calculated, only kept when shouldLog is true */
+	damageCalculations []*DamageCalculation
+	eventListeners     []BattleEventListener
+	lastDamagedBy      map[*MonsterCard]GameCardInterface
+	preGameHooks       []func(g *Game)
+	config             *EngineConfig
+	// handlers replaced in this game only
+	abilityHandlerOverrides map[Ability]AbilityHandler
+	randomSource            RandomSource
+	// behaviors of the rules version of the game
+	rulesBehavior RulesBehavior
 }
 
 func (g *Game) Create(team1, team2 *GameTeam, rulesets []Ruleset, shouldLog bool) {
+	g.CreateWithConfig(team1, team2, rulesets, shouldLog, DefaultEngineConfig())
+}
+
+/* Same as Create but the game uses the balance parameters of the config */
+func (g *Game) CreateWithConfig(team1, team2 *GameTeam, rulesets []Ruleset, shouldLog bool, config EngineConfig) {
+	g.config = &config
+	g.rulesBehavior = RulesBehavior{}
 	g.team1 = team1
 	g.team2 = team2
 	g.rulesets = rulesets
@@ -30,6 +48,7 @@ func (g *Game) Create(team1, team2 *GameTeam, rulesets []Ruleset, shouldLog bool
 	g.team1.SetTeamNumber(TEAM_NUM_ONE)
 	g.team2.SetTeamNumber(TEAM_NUM_TWO)
 	g.stunData = make(map[string][]*MonsterCard, 0)
+	g.lastDamagedBy = make(map[*MonsterCard]GameCardInterface)
 }
 
 func (g *Game) Reset() {
@@ -38,8 +57,23 @@ func (g *Game) Reset() {
 	g.deadMonsters = make([]*MonsterCard, 0)
 	g.team1.ResetTeam()
 	g.team2.ResetTeam()
+	g.shareAbilityHandlerOverrides()
 	g.stunData = make(map[string][]*MonsterCard, 0)
 	g.battleLogs = []BattleLog{}
+	g.damageCalculations = []*DamageCalculation{}
+	g.lastDamagedBy = make(map[*MonsterCard]GameCardInterface)
+}
+
+/* Adds a function that runs at the start of every PlayGame, right after the teams are reset. e.g. to change the cards of a game */
+func (g *Game) AddPreGameHook(hook func(g *Game)) {
+	g.preGameHooks = append(g.preGameHooks, hook)
+}
+
+func (g *Game) GetTeam(teamNumber TeamNumber) *GameTeam {
+	if teamNumber == TEAM_NUM_ONE {
+		return g.team1
+	}
+	return g.team2
 }
 
 func (g *Game) GetWinner() TeamNumber {
@@ -72,8 +106,18 @@ func (g *Game) GetBattleLogs() ([]BattleLog, error) {
 	return g.battleLogs, nil
 }
 
+func (g *Game) GetDamageCalculations() ([]*DamageCalculation, error) {
+	if !g.shouldLog {
+		return []*DamageCalculation{}, errors.New("you must instantiate the game with enableLogs as true")
+	}
+	return g.damageCalculations, nil
+}
+
 func (g *Game) PlayGame() {
 	g.Reset()
+	for _, hook := range g.preGameHooks {
+		hook(g)
+	}
 	team1Summoner := g.team1.GetSummoner()
 	team1Monsters := g.team1.GetMonstersList()
 	team2Summoner := g.team2.GetSummoner()
@@ -97,6 +141,10 @@ func (g *Game) PlayGame() {
 	// Monsters pre-game debuffs
 	g.DoMonsterPreGameDebuff(team1Monsters, team2Monsters)
 
+	// Monsters pre-game abilities
+	g.doAbilitiesPreGame(team1Monsters)
+	g.doAbilitiesPreGame(team2Monsters)
+
 	// Apply ruleset rules that apply post buff phase
 	DoRulesetPreGamePostBuff(g.rulesets, g.team1, g.team2)
 
@@ -273,8 +321,10 @@ func (g *Game) PlayRoundsUntilGameEnd(roundNumber int) {
 		g.LogGameOver()
 	}
 
+	g.emitEvent(BattleEvent{Type: BATTLE_EVENT_ROUND_START})
+
 	// Fatigue
-	if roundNumber >= FATIGUE_ROUND_NUMBER {
+	if roundNumber >= g.GetConfig().FatigueRoundNumber {
 		g.FatigueMonsters(roundNumber)
 		g.CheckAndSetGameWinner()
 		if g.winner != TEAM_NUM_UNKNOWN {
@@ -315,12 +365,14 @@ func (g *Game) LogGameOver() {
 }
 
 func (g *Game) FatigueMonsters(roundNumber int) {
-	fatigueDamage := roundNumber - FATIGUE_ROUND_NUMBER + 1
+	fatigueDamage := roundNumber - g.GetConfig().FatigueRoundNumber + 1
 	allAliveMonsters := g.GetAllAliveMonsters()
 
 	for _, m := range allAliveMonsters {
 		g.CreateAndAddBattleLog(BATTLE_ACTION_FATIGUE, m, nil, fatigueDamage)
+		preHitHealth := m.Health
 		m.HitHealth(fatigueDamage)
+		g.emitHealthDamageEvent(BATTLE_ACTION_FATIGUE, ATTACK_TYPE_NO_ATTACK, nil, m, preHitHealth-m.Health)
 		g.ProcessIfDead(m)
 	}
 
@@ -377,6 +429,7 @@ func (g *Game) ProcessIfDead(m *MonsterCard) {
 
 	// monster is dead
 	g.CreateAndAddBattleLog(BATTLE_ACTION_DEATH, m, nil, 0)
+	g.emitEvent(BattleEvent{Type: BATTLE_EVENT_DEATH, Source: BATTLE_ACTION_DEATH, Actor: g.lastDamagedBy[m], Target: m})
 	g.deadMonsters = append(g.deadMonsters, m)
 	m.SetHasTurnPassed(true)
 
@@ -385,18 +438,8 @@ func (g *Game) ProcessIfDead(m *MonsterCard) {
 	enemyTeam := g.GetEnemyTeamOfMonster(m)
 	aliveEnemyMonsters := enemyTeam.GetAliveMonsters()
 
-	// Redemption
-	if m.HasAbility(ABILITY_REDEMPTION) {
-		for _, e := range aliveEnemyMonsters {
-			HitMonsterWithPhysical(
-				g,
-				e,
-				REDEMPTION_DAMAGE,
-			)
-
-			g.ProcessIfDead(e)
-		}
-	}
+	// Redemption, ...
+	g.doAbilitiesOnDeath(m)
 
 	// Ressurect
 	friendlySummoner := friendlyTeam.GetSummoner()
@@ -465,12 +508,14 @@ func (g *Game) PlaySingleRound() {
 
 		// check stun
 		if currentMonster.HasDebuff(ABILITY_STUN) {
+			g.emitEvent(BattleEvent{Type: BATTLE_EVENT_TURN_STUNNED, Actor: currentMonster})
 			currentMonster.SetHasTurnPassed(true)
 			currentMonster = g.GetNextMonsterTurn()
 			continue
 		}
 
 		// handle monster attack
+		g.emitEvent(BattleEvent{Type: BATTLE_EVENT_TURN_TAKEN, Actor: currentMonster})
 		g.DoMonsterPreTurn(currentMonster)
 		g.ResolveAttackForMonster(currentMonster)
 		if currentMonster.HasAbility(ABILITY_DOUBLE_STRIKE) {
@@ -482,7 +527,9 @@ func (g *Game) PlaySingleRound() {
 }
 
 func (g *Game) DoGamePreRound() {
-	// Add pre round actions when necessary
+	for _, h := range GetRulesetHandlers(g.rulesets) {
+		h.OnPreRound(g)
+	}
 }
 
 // Handle Summoner's pre turn actions (e.g. cleanse, tank heal, repair, triage)
@@ -494,30 +541,34 @@ func (g *Game) DoSummonerPreRound(t *GameTeam) {
 		firstMonster := t.GetFirstAliveMonster()
 		firstMonster.CleanseDebuffs()
 		g.CreateAndAddBattleLog(BATTLE_ACTION_CLEANSE, summoner, firstMonster, 0)
+		g.emitEvent(BattleEvent{Type: BATTLE_EVENT_CLEANSE, Source: BATTLE_ACTION_CLEANSE, Actor: summoner, Target: firstMonster})
 	}
 
 	// Repair
 	if summoner.HasAbility(ABILITY_REPAIR) {
 		repairTarget := t.GetRepairTarget()
 		if repairTarget != nil {
-			RepairMonsterArmor(repairTarget)
+			repairAmount := RepairMonsterArmor(repairTarget)
 			g.CreateAndAddBattleLog(BATTLE_ACTION_REPAIR, summoner, repairTarget, 0)
+			g.emitEvent(BattleEvent{Type: BATTLE_EVENT_REPAIR, Source: BATTLE_ACTION_REPAIR, Actor: summoner, Target: repairTarget, Value: repairAmount})
 		}
 	}
 
 	// Tank heal
 	if summoner.HasAbility(ABILITY_TANK_HEAL) {
 		firstMonster := t.GetFirstAliveMonster()
-		TankHealMonster(firstMonster)
+		healAmount := TankHealMonster(firstMonster, g.GetConfig().TankHealMultiplier)
 		g.CreateAndAddBattleLog(BATTLE_ACTION_TANK_HEAL, summoner, firstMonster, 0)
+		g.emitEvent(BattleEvent{Type: BATTLE_EVENT_HEAL, Source: BATTLE_ACTION_TANK_HEAL, Actor: summoner, Target: firstMonster, Value: healAmount})
 	}
 
 	// Triage
 	if summoner.HasAbility(ABILITY_TRIAGE) {
 		healTarget := t.GetTriageHealTarget()
 		if healTarget != nil {
-			healAmount := TriageHealMonster(healTarget)
+			healAmount := TriageHealMonster(healTarget, g.GetConfig().TriageHealMultiplier)
 			g.CreateAndAddBattleLog(BATTLE_ACTION_TRIAGE, summoner, healTarget, healAmount)
+			g.emitEvent(BattleEvent{Type: BATTLE_EVENT_HEAL, Source: BATTLE_ACTION_TRIAGE, Actor: summoner, Target: healTarget, Value: healAmount})
 		}
 	}
 }
@@ -541,18 +592,28 @@ func (g *Game) GetNextMonsterTurn() *MonsterCard {
 		}
 
 		// resolve tie by order if the same team, else random
+		tieBreaker := func() int {
+			return g.RollTieBreaker(m1, m2)
+		}
 		if m1.GetTeamNumber() == m2.GetTeamNumber() {
-			return ResolveFriendlyTies(m1, m2) > 0
+			return resolveFriendlyTiesWith(m1, m2, tieBreaker) > 0
 		} else {
-			return RandomTieBreaker() > 0
+			return tieBreaker() > 0
 		}
 	})
 
-	if utils.Contains(g.rulesets, RULESET_REVERSE_SPEED) {
-		return allUnmovedMonsters[0]
+	// fastest first
+	turnOrder := make([]*MonsterCard, 0, len(allUnmovedMonsters))
+	for i := len(allUnmovedMonsters) - 1; i >= 0; i-- {
+		turnOrder = append(turnOrder, allUnmovedMonsters[i])
 	}
-
-	return allUnmovedMonsters[len(allUnmovedMonsters)-1]
+	for _, h := range GetRulesetHandlers(g.rulesets) {
+		turnOrder = h.ModifyTurnOrder(g, turnOrder)
+	}
+	if len(turnOrder) == 0 {
+		return nil
+	}
+	return turnOrder[0]
 }
 
 func (g *Game) DoMonsterPreTurn(m *MonsterCard) {
@@ -564,13 +625,15 @@ func (g *Game) DoMonsterPreTurn(m *MonsterCard) {
 		cleanseTarget := friendlyTeam.GetFirstAliveMonster()
 		cleanseTarget.CleanseDebuffs()
 		g.CreateAndAddBattleLog(BATTLE_ACTION_CLEANSE, m, cleanseTarget, 0)
+		g.emitEvent(BattleEvent{Type: BATTLE_EVENT_CLEANSE, Source: BATTLE_ACTION_CLEANSE, Actor: m, Target: cleanseTarget})
 	}
 
 	// Tank heal
 	if m.HasAbility(ABILITY_TANK_HEAL) {
 		tankHealTarget := friendlyTeam.GetFirstAliveMonster()
-		healAmount := TankHealMonster(tankHealTarget)
+		healAmount := TankHealMonster(tankHealTarget, g.GetConfig().TankHealMultiplier)
 		g.CreateAndAddBattleLog(BATTLE_ACTION_TANK_HEAL, m, tankHealTarget, healAmount)
+		g.emitEvent(BattleEvent{Type: BATTLE_EVENT_HEAL, Source: BATTLE_ACTION_TANK_HEAL, Actor: m, Target: tankHealTarget, Value: healAmount})
 	}
 
 	// Repair
@@ -579,6 +642,7 @@ func (g *Game) DoMonsterPreTurn(m *MonsterCard) {
 		if repairTarget != nil {
 			repairAmount := RepairMonsterArmor(repairTarget)
 			g.CreateAndAddBattleLog(BATTLE_ACTION_REPAIR, m, repairTarget, repairAmount)
+			g.emitEvent(BattleEvent{Type: BATTLE_EVENT_REPAIR, Source: BATTLE_ACTION_REPAIR, Actor: m, Target: repairTarget, Value: repairAmount})
 		}
 	}
 
@@ -586,8 +650,9 @@ func (g *Game) DoMonsterPreTurn(m *MonsterCard) {
 	if m.HasAbility(ABILITY_TRIAGE) {
 		triageTarget := friendlyTeam.GetTriageHealTarget()
 		if triageTarget != nil {
-			triageAmount := TriageHealMonster(triageTarget)
+			triageAmount := TriageHealMonster(triageTarget, g.GetConfig().TriageHealMultiplier)
 			g.CreateAndAddBattleLog(BATTLE_ACTION_TRIAGE, m, triageTarget, triageAmount)
+			g.emitEvent(BattleEvent{Type: BATTLE_EVENT_HEAL, Source: BATTLE_ACTION_TRIAGE, Actor: m, Target: triageTarget, Value: triageAmount})
 		}
 	}
 
@@ -595,7 +660,10 @@ func (g *Game) DoMonsterPreTurn(m *MonsterCard) {
 	if m.HasAbility(ABILITY_HEAL) {
 		healAmount := SelfHealMonster(m)
 		g.CreateAndAddBattleLog(BATTLE_ACTION_HEAL, m, m, healAmount)
+		g.emitEvent(BattleEvent{Type: BATTLE_EVENT_HEAL, Source: BATTLE_ACTION_HEAL, Actor: m, Target: m, Value: healAmount})
 	}
+
+	g.doAbilitiesPreTurn(m)
 }
 
 func (g *Game) ResolveAttackForMonster(attacker *MonsterCard) {
@@ -632,7 +700,7 @@ func (g *Game) ResolveAttackForMonster(attacker *MonsterCard) {
 	// Check Bloodlust
 	deadMonstersCount := len(g.deadMonsters) - currentDeadMonstersCount
 	if attacker.HasAbility(ABILITY_BLOODLUST) && deadMonstersCount > 0 {
-		isReverseSpeed := utils.Contains(g.rulesets, RULESET_REVERSE_SPEED)
+		isReverseSpeed := g.HasRuleset(RULESET_REVERSE_SPEED)
 		if deadMonstersCount > 1 {
 			// two monsters might die from one attack with blast
 			for i := 0; i < deadMonstersCount; i++ {
@@ -685,28 +753,31 @@ func (g *Game) GetTargetForNonMelee(m *MonsterCard) *MonsterCard {
 	enemyTeam := g.GetEnemyTeamOfMonster(m)
 	// Scattershot target
 	if m.HasAbility(ABILITY_SCATTERSHOT) {
-		return enemyTeam.GetScattershotTarget()
+		g.describeDraw(m, nil)
+		return enemyTeam.GetScattershotTargetFrom(g.GetRandomSource())
 	}
 
 	// Taunt
+	useTaunt := !g.rulesBehavior.TauntIgnoredByTargetAbilities
+	hasTargetAbility := m.HasAbility(ABILITY_SNEAK) || m.HasAbility(ABILITY_SNIPE) || m.HasAbility(ABILITY_OPPORTUNITY)
 	tauntMonster := enemyTeam.GetTauntMonster()
-	if tauntMonster != nil {
+	if tauntMonster != nil && (useTaunt || !hasTargetAbility) {
 		return tauntMonster
 	}
 
 	// Sneak target
 	if m.HasAbility(ABILITY_SNEAK) {
-		return enemyTeam.GetSneakTarget()
+		return enemyTeam.getSneakTarget(useTaunt)
 	}
 
 	// Snipe target
 	if m.HasAbility(ABILITY_SNIPE) {
-		return enemyTeam.GetSnipeTarget()
+		return enemyTeam.getSnipeTarget(useTaunt)
 	}
 
 	// Opportunity
 	if m.HasAbility(ABILITY_OPPORTUNITY) {
-		return enemyTeam.GetOpportunityTarget()
+		return enemyTeam.getOpportunityTarget(useTaunt)
 	}
 
 	return enemyTeam.GetFirstAliveMonster()
@@ -746,13 +817,14 @@ func (g *Game) GetTargetForMeleeAttack(m *MonsterCard) *MonsterCard {
 	}
 
 	// Sneak target
+	useTaunt := !g.rulesBehavior.TauntIgnoredByTargetAbilities
 	if m.HasAbility(ABILITY_SNEAK) {
-		return enemyTeam.GetSneakTarget()
+		return enemyTeam.getSneakTarget(useTaunt)
 	}
 
 	// Opportunity
 	if m.HasAbility(ABILITY_OPPORTUNITY) {
-		return enemyTeam.GetOpportunityTarget()
+		return enemyTeam.getOpportunityTarget(useTaunt)
 	}
 
 	// Melee mayhem
@@ -776,15 +848,18 @@ func (g *Game) AttackMonsterPhase(attacker, target *MonsterCard, attackType Card
 	if attacker.HasAbility(ABILITY_RECHARGE) && g.roundNumber%2 == 0 {
 		return
 	}
-	isAimTrue := utils.Contains(g.rulesets, RULESET_AIM_TRUE)
-	wasAttackDoged := GetDidDodge(g.rulesets, attacker, target, attackType)
-	if !isAimTrue && wasAttackDoged {
+	wasAttackDoged := g.RollDodge(attacker, target, attackType)
+	if wasAttackDoged {
 		g.CreateAndAddBattleLog(BATTLE_ACTION_ATTACK_DODGED, attacker, target, 0)
+		g.emitEvent(BattleEvent{Type: BATTLE_EVENT_DODGE, Source: BATTLE_ACTION_ATTACK_DODGED, Actor: target, Target: attacker})
 		g.MaybeApplyBackFire(attacker, target, attackType)
 		// no more calculation since attack was dodged
 		return
 	}
 
+	hit := &AbilityHitContext{Attacker: attacker, Target: target, AttackType: attackType}
+	g.doAbilitiesOnAttack(hit)
+
 	// Prepare variables
 	damageMultiplier, _ := g.GetDamageMultiplier(attacker, target)
 	baseDamage := attacker.GetPostAbilityAttackOfType(attackType)
@@ -802,42 +877,62 @@ func (g *Game) AttackMonsterPhase(attacker, target *MonsterCard, attackType Card
 		nextMonster = attackedTeamAliveMonsters[attackedPosition+1]
 	}
 
+	hit.DamageAmount = damageAmount
+	hit.PrevMonster = prevMonster
+	hit.NextMonster = nextMonster
+
 	// Snare the target monster
 	if target.HasAbility(ABILITY_FLYING) && attacker.HasAbility(ABILITY_SNARE) && !target.HasDebuff(ABILITY_SNARE) {
 		g.AddMonsterDebuffToAMonster(attacker, target, ABILITY_SNARE, BATTLE_ACTION_SNARE)
 	}
 
 	// Divine shield
-	if target.HasAbility(ABILITY_DIVINE_SHIELD) {
-		g.HandleDivineShield(attacker, target, attackType, baseDamage, prevMonster, nextMonster, damageAmount)
+	if g.doAbilitiesBeforeBeingHit(hit) {
+		if g.isRecordingDamage() {
+			calc := &DamageCalculation{Target: target, IncomingDamage: hit.DamageAmount, DivineShieldAbsorbed: true}
+			g.fillAttackSideOfCalculation(calc, attacker, target, attackType)
+			g.recordDamageCalculation(GetAttackBattleAction(attackType), attacker, BattleDamage{Calculation: calc})
+		}
+		hit.AttackDamage = baseDamage
+		g.HandleDivineShield(hit)
 		return
 	}
 
 	battleDamage := g.ActuallyHitMonster(attacker, target, attackType)
 	g.CreateAndAddBattleLog(BATTLE_ACTION_ATTACK, attacker, target, battleDamage.DamageDone)
+	calc := battleDamage.Calculation
 
 	// Pierce
-	if attacker.HasAbility(ABILITY_PIERCING) && battleDamage.Remainder > 0 {
+	canPierce := attackType == ATTACK_TYPE_MELEE || !g.rulesBehavior.PiercingMeleeOnly
+	if attacker.HasAbility(ABILITY_PIERCING) && canPierce && battleDamage.Remainder > 0 {
 		// remainder already halved by shield or void. it just needs to hit health
+		preHitHealth := target.Health
 		remainderDamage := HitHealth(target, battleDamage.Remainder)
+		if calc != nil {
+			calc.PiercingDamage = preHitHealth - target.Health
+		}
+		g.emitHealthDamageEvent(BATTLE_ACTION_PIERCING_REMAINDER, attackType, attacker, target, preHitHealth-target.Health)
 		g.CreateAndAddBattleLog(BATTLE_ACTION_PIERCING_REMAINDER, attacker, target, remainderDamage)
 	}
 
 	// TODO: this doesn't account for the pierce
-	g.MaybeApplyLifeLeech(attacker, battleDamage.ActualDamageDone)
-	g.MaybeApplyThorns(attacker, target, attackType)
-	g.MaybeApplyMagicReflect(attacker, target, attackType, attacker.GetPostAbilityAttackOfType(attackType))
-	g.MaybeApplyReturnFire(attacker, target, attackType, attacker.GetPostAbilityAttackOfType(attackType))
-	g.MaybeApplyRetaliate(attacker, target, attackType)
+	lifeLeechAmount := g.MaybeApplyLifeLeech(attacker, battleDamage.ActualDamageDone)
+	if lifeLeechAmount > 0 {
+		g.addDamageSideEffect(calc, ABILITY_LIFE_LEECH, attacker, lifeLeechAmount)
+	}
+
+	// Thorns, Magic Reflect, Return Fire, Retaliate, ...
+	hit.AttackDamage = attacker.GetPostAbilityAttackOfType(attackType)
+	hit.Damage = battleDamage
+	g.doAbilitiesOnBeingHit(hit)
 	g.MaybeApplyHalving(attacker, target)
 
 	// check if dead
 	g.ProcessIfDead(attacker)
 	g.ProcessIfDead(target)
 
-	// check blast
-	g.MaybeApplyBlast(attacker, prevMonster, attackType, damageAmount)
-	g.MaybeApplyBlast(attacker, nextMonster, attackType, damageAmount)
+	// Blast, ...
+	g.doAbilitiesOnHit(hit)
 
 	// Shatter
 	if attacker.HasAbility(ABILITY_SHATTER) {
@@ -854,7 +949,7 @@ func (g *Game) AttackMonsterPhase(attacker, target *MonsterCard, attackType Card
 	g.MaybeApplyCripple(attacker, target)
 
 	// Affliction
-	if attacker.HasAbility(ABILITY_AFFLICTION) && !target.HasDebuff(ABILITY_AFFLICTION) && GetSuccessBelow(AFFLICTION_CHANCE*100) {
+	if attacker.HasAbility(ABILITY_AFFLICTION) && !target.HasDebuff(ABILITY_AFFLICTION) && g.RollSuccessBelow(RANDOM_EVENT_AFFLICTION, g.GetConfig().AfflictionChance*100, attacker, target) {
 		g.CreateAndAddBattleLog(BATTLE_ACTION_AFFLICTION, attacker, target, battleDamage.DamageDone)
 		g.AddMonsterDebuffToAMonster(attacker, target, ABILITY_AFFLICTION, BATTLE_ACTION_AFFLICTION)
 	}
@@ -872,7 +967,8 @@ func (g *Game) MaybeApplyBackFire(attacker, target *MonsterCard, attackType Card
 		return false
 	}
 
-	backfireBattleDamage := HitMonsterWithPhysical(g, target, BACKFIRE_DAMAGE)
+	backfireBattleDamage := HitMonsterWithPhysical(g, target, g.GetConfig().BackfireDamage)
+	g.recordDamageCalculation(BATTLE_ACTION_BACKFIRE, attacker, backfireBattleDamage)
 	g.CreateAndAddBattleLog(BATTLE_ACTION_BACKFIRE, attacker, target, backfireBattleDamage.ActualDamageDone)
 	// attacker gets damage from backfire and might die from it
 	g.ProcessIfDead(attacker)
@@ -920,16 +1016,10 @@ func (g *Game) AddMonsterDebuffToAMonster(caster *MonsterCard, target *MonsterCa
 	g.CreateAndAddBattleLog(battleAction, caster, target, 0)
 }
 
-func (g *Game) HandleDivineShield(
-	attacker, target *MonsterCard,
-	attackType CardAttackType,
-	AttackDamageForReflections int,
-	prevMonster *MonsterCard,
-	nextMonster *MonsterCard,
-	damageAmount int,
-) {
-	target.RemoveDivineShield()
-	g.CreateAndAddBattleLog(BATTLE_ACTION_REMOVE_DIVINE_SHIELD, attacker, target, 0)
+/* Resolves an attack absorbed by an ability of the target (Divine Shield), the absorbing ability was already used up */
+func (g *Game) HandleDivineShield(hit *AbilityHitContext) {
+	attacker, target, attackType := hit.Attacker, hit.Target, hit.AttackType
+	AttackDamageForReflections := hit.AttackDamage
 
 	// Handle Reflective Damage
 	if attackType == ATTACK_TYPE_MAGIC {
@@ -959,7 +1049,7 @@ func (g *Game) HandleDivineShield(
 
 	// Debuffs
 	// Affliction
-	if attacker.HasAbility(ABILITY_AFFLICTION) && !target.HasDebuff(ABILITY_AFFLICTION) && GetSuccessBelow(AFFLICTION_CHANCE*100) {
+	if attacker.HasAbility(ABILITY_AFFLICTION) && !target.HasDebuff(ABILITY_AFFLICTION) && g.RollSuccessBelow(RANDOM_EVENT_AFFLICTION, g.GetConfig().AfflictionChance*100, attacker, target) {
 		g.CreateAndAddBattleLog(BATTLE_ACTION_AFFLICTION, attacker, target, 0)
 		g.AddMonsterDebuffToAMonster(attacker, target, ABILITY_AFFLICTION, BATTLE_ACTION_AFFLICTION)
 	}
@@ -969,8 +1059,8 @@ func (g *Game) HandleDivineShield(
 		DispelBuffs(target)
 	}
 
-	g.MaybeApplyBlast(attacker, prevMonster, attackType, damageAmount)
-	g.MaybeApplyBlast(attacker, nextMonster, attackType, damageAmount)
+	// Blast, ...
+	g.doAbilitiesOnHit(hit)
 }
 
 // Resolve attack involves Trample
@@ -987,7 +1077,7 @@ func (g *Game) ResolveMeleeAttackForMonster(attacker, target *MonsterCard, attac
 
 	// Check Trample
 	if !hasTrampled && nextMonster != nil && attacker.HasAbility(ABILITY_TRAMPLE) && len(g.deadMonsters) > beforeAttackDeadMonsterCount {
-		g.ResolveMeleeAttackForMonster(attacker, nextMonster, attackType, utils.Contains(g.rulesets, RULESET_STAMPEDE))
+		g.ResolveMeleeAttackForMonster(attacker, nextMonster, attackType, g.HasRuleset(RULESET_STAMPEDE))
 	}
 }
 
@@ -1019,99 +1109,42 @@ func (g *Game) MaybeApplyBloodlust(attacker *MonsterCard, isReverseSpeed bool) {
 	g.CreateAndAddBattleLog(BATTLE_ACTION_BLOODLUST, attacker, nil, 0)
 }
 
-func (g *Game) MaybeApplyMagicReflect(attacker *MonsterCard, target *MonsterCard, attackType CardAttackType, attackDamageForReflections int) {
-	if !target.HasAbility(ABILITY_MAGIC_REFLECT) || attackType != ATTACK_TYPE_MAGIC {
-		return
-	}
-
-	attackDamage := 0
-	if attackDamageForReflections != 0 {
-		attackDamage = attackDamageForReflections
-	}
-
-	// half of the magic attack damage (round up)
-	reflectDamage := int(math.Ceil(float64(attackDamage) / 2))
-
-	// Amplify
-	if attacker.HasDebuff(ABILITY_AMPLIFY) {
-		reflectDamage += 1
-	}
-
-	// Reflection shield
-	if attacker.HasAbility(ABILITY_REFLECTION_SHIELD) {
-		reflectDamage = 0
-	}
-
-	battleDamage := HitMonsterWithMagic(g, attacker, reflectDamage)
-	g.CreateAndAddBattleLog(BATTLE_ACTION_MAGIC_REFLECT, attacker, target, battleDamage.DamageDone)
+func (g *Game) MaybeApplyMagicReflect(attacker *MonsterCard, target *MonsterCard, attackType CardAttackType, attackDamageForReflections int) BattleDamage {
+	return g.applyBeingHitAbility(ABILITY_MAGIC_REFLECT, &AbilityHitContext{Attacker: attacker, Target: target, AttackType: attackType, AttackDamage: attackDamageForReflections})
 }
 
-func (g *Game) MaybeApplyReturnFire(attacker *MonsterCard, target *MonsterCard, attackType CardAttackType, attackDamageForReflections int) {
-	if !target.HasAbility(ABILITY_RETURN_FIRE) || attackType != ATTACK_TYPE_RANGED {
-		return
-	}
-
-	attackDamage := 0
-	if attackDamageForReflections != 0 {
-		attackDamage = attackDamageForReflections
-	}
-
-	// half of the ranged attack damage (round up)
-	reflectDamage := int(math.Ceil(float64(attackDamage) / 2))
-
-	// Amplify
-	if attacker.HasDebuff(ABILITY_AMPLIFY) {
-		reflectDamage += 1
-	}
-
-	// Reflection shield
-	if attacker.HasAbility(ABILITY_REFLECTION_SHIELD) {
-		reflectDamage = 0
-	}
-
-	battleDamage := HitMonsterWithPhysical(g, attacker, reflectDamage)
-	g.CreateAndAddBattleLog(BATTLE_ACTION_RETURN_FIRE, attacker, target, battleDamage.DamageDone)
+func (g *Game) MaybeApplyReturnFire(attacker *MonsterCard, target *MonsterCard, attackType CardAttackType, attackDamageForReflections int) BattleDamage {
+	return g.applyBeingHitAbility(ABILITY_RETURN_FIRE, &AbilityHitContext{Attacker: attacker, Target: target, AttackType: attackType, AttackDamage: attackDamageForReflections})
 }
 
-func (g *Game) MaybeApplyThorns(attacker *MonsterCard, target *MonsterCard, attackType CardAttackType) {
-	if !target.HasAbility(ABILITY_THORNS) || attackType != ATTACK_TYPE_MELEE {
-		return
-	}
-
-	// Thorns always do the same damage amount
-	reflectDamage := THORNS_DAMAGE
-
-	// Amplify
-	if attacker.HasDebuff(ABILITY_AMPLIFY) {
-		reflectDamage += 1
-	}
-
-	// Reflection shield
-	if attacker.HasAbility(ABILITY_REFLECTION_SHIELD) {
-		reflectDamage = 0
-	}
-
-	battleDamage := HitMonsterWithPhysical(g, attacker, reflectDamage)
-	g.CreateAndAddBattleLog(BATTLE_ACTION_THORNS, attacker, target, battleDamage.DamageDone)
+func (g *Game) MaybeApplyThorns(attacker *MonsterCard, target *MonsterCard, attackType CardAttackType) BattleDamage {
+	return g.applyBeingHitAbility(ABILITY_THORNS, &AbilityHitContext{Attacker: attacker, Target: target, AttackType: attackType})
 }
 
 func (g *Game) MaybeApplyRetaliate(attacker *MonsterCard, target *MonsterCard, attackType CardAttackType) {
-	if attacker == nil || target == nil || !target.HasAbility(ABILITY_RETALIATE) || attackType != ATTACK_TYPE_MELEE {
-		return
-	}
+	g.applyBeingHitAbility(ABILITY_RETALIATE, &AbilityHitContext{Attacker: attacker, Target: target, AttackType: attackType})
+}
 
-	// Retaliate chance
-	doesRetaliate := GetSuccessBelow(RETALIATE_CHANCE * 100)
-	if !doesRetaliate {
+/* Runs the on-hit hook of the registered handler of the ability */
+func (g *Game) applyHitAbility(ability Ability, hit *AbilityHitContext) {
+	handler := g.GetAbilityHandler(ability)
+	if handler == nil || hit.Attacker == nil {
 		return
 	}
+	handler.OnHit(g, hit.Attacker, hit)
+}
 
-	g.CreateAndAddBattleLog(BATTLE_ACTION_RETALIATE, target, attacker, attacker.GetPostAbilityMelee())
-	g.AttackMonsterPhase(target, attacker, ATTACK_TYPE_MELEE)
+/* Runs the on-being-hit hook of the registered handler of the ability */
+func (g *Game) applyBeingHitAbility(ability Ability, hit *AbilityHitContext) BattleDamage {
+	handler := g.GetAbilityHandler(ability)
+	if handler == nil || hit.Target == nil {
+		return BattleDamage{}
+	}
+	return handler.OnBeingHit(g, hit.Target, hit)
 }
 
 func (g *Game) MaybeApplyStun(attacker, target *MonsterCard) {
-	if attacker.HasAbility(ABILITY_STUN) && GetSuccessBelow(STUN_CHANCE*100) {
+	if attacker.HasAbility(ABILITY_STUN) && g.RollSuccessBelow(RANDOM_EVENT_STUN, g.GetConfig().StunChance*100, attacker, target) {
 		stunDataKey := g.GetStunDataKey(g.roundNumber, attacker)
 		prevStunnedMonsters := g.stunData[stunDataKey]
 		if prevStunnedMonsters == nil {
@@ -1124,7 +1157,7 @@ func (g *Game) MaybeApplyStun(attacker, target *MonsterCard) {
 }
 
 func (g *Game) MaybeApplyPoison(attacker, target *MonsterCard) {
-	if attacker.HasAbility(ABILITY_POISON) && GetSuccessBelow(POISON_CHANCE*100) && !target.HasDebuff(ABILITY_POISON) && target.IsAlive() {
+	if attacker.HasAbility(ABILITY_POISON) && !target.HasDebuff(ABILITY_POISON) && target.IsAlive() && g.RollSuccessBelow(RANDOM_EVENT_POISON, g.GetConfig().PoisonChance*100, attacker, target) {
 		g.AddMonsterDebuffToAMonster(attacker, target, ABILITY_POISON, BATTLE_ACTION_POISON)
 	}
 }
@@ -1141,9 +1174,10 @@ func (g *Game) MaybeApplyHalving(attacker, target *MonsterCard) {
 	}
 }
 
-func (g *Game) MaybeApplyLifeLeech(attacker *MonsterCard, damage int) {
+// Returns the amount of health leeched
+func (g *Game) MaybeApplyLifeLeech(attacker *MonsterCard, damage int) int {
 	if !attacker.IsAlive() || !attacker.HasAbility(ABILITY_LIFE_LEECH) {
-		return
+		return 0
 	}
 
 	lifeLeechAmount := int(math.Ceil(float64(damage) / 2))
@@ -1153,49 +1187,11 @@ func (g *Game) MaybeApplyLifeLeech(attacker *MonsterCard, damage int) {
 		}
 		g.CreateAndAddBattleLog(BATTLE_ACTION_LIFE_LEECH, attacker, nil, lifeLeechAmount)
 	}
+	return lifeLeechAmount
 }
 
-// Blast has a ton of edge cases... https://support.splinterlands.com/hc/en-us/articles/4414966685332-Abilities-Status-Effects
 func (g *Game) MaybeApplyBlast(attacker, blastTarget *MonsterCard, attackType CardAttackType, damageAmount int) {
-	if !attacker.HasAbility(ABILITY_BLAST) || blastTarget == nil {
-		return
-	}
-
-	baseBlastDamage := int(math.Ceil(float64(damageAmount) / 2))
-	damageMultiplier := g.GetPostBlastDamageMultiplier(attacker, blastTarget)
-	blastDamage := baseBlastDamage * damageMultiplier
-
-	// Forcefield
-	if blastTarget.HasAbility(ABILITY_FORCEFIELD) && blastDamage >= FORCEFIELD_MIN_DAMAGE {
-		blastDamage = 1
-	}
-
-	// Snare
-	if blastTarget.HasAbility(ABILITY_FLYING) && attacker.HasAbility(ABILITY_SNARE) && !blastTarget.HasDebuff(ABILITY_SNARE) {
-		g.AddMonsterDebuffToAMonster(attacker, blastTarget, ABILITY_SNARE, BATTLE_ACTION_SNARE)
-	}
-
-	// Reflection shield
-	if blastTarget.HasAbility(ABILITY_REFLECTION_SHIELD) {
-		blastDamage = 0
-	}
-
-	// Magic blast damage
-	if attackType == ATTACK_TYPE_MAGIC {
-		battleDamage := HitMonsterWithMagic(g, blastTarget, blastDamage)
-		g.CreateAndAddBattleLog(BATTLE_ACTION_BLAST, attacker, blastTarget, battleDamage.DamageDone)
-		g.MaybeApplyMagicReflect(attacker, blastTarget, attackType, battleDamage.Attack)
-		g.MaybeApplyLifeLeech(attacker, (blastDamage - battleDamage.Remainder))
-	} else {
-		// melee or range attack
-		battleDamage := HitMonsterWithPhysical(g, blastTarget, blastDamage)
-		g.CreateAndAddBattleLog(BATTLE_ACTION_BLAST, attacker, blastTarget, battleDamage.DamageDone)
-		g.MaybeApplyReturnFire(attacker, blastTarget, attackType, battleDamage.Attack)
-	}
-
-	// check dead monster
-	g.ProcessIfDead(blastTarget)
-	g.ProcessIfDead(attacker)
+	g.applyHitAbility(ABILITY_BLAST, &AbilityHitContext{Attacker: attacker, AttackType: attackType, DamageAmount: damageAmount, PrevMonster: blastTarget})
 }
 
 func (g *Game) GetPostBlastDamageMultiplier(attacker, blastTarget *MonsterCard) int {
@@ -1225,26 +1221,26 @@ func (g *Game) GetPostBlastDamageMultiplier(attacker, blastTarget *MonsterCard)
 }
 
 func (g *Game) DoPostRound() {
+	// Earthquake, ...
+	for _, h := range GetRulesetHandlers(g.rulesets) {
+		h.OnPostRound(g)
+	}
 	aliveTeam1 := g.team1.GetAliveMonsters()
 	aliveTeam2 := g.team2.GetAliveMonsters()
 
-	// Earthquake
-	if utils.Contains(g.rulesets, RULESET_EARTHQUAKE) {
-		g.DoPostRoundEarthquake(aliveTeam1)
-		g.DoPostRoundEarthquake(aliveTeam2)
-		aliveTeam1 = g.team1.GetAliveMonsters()
-		aliveTeam2 = g.team2.GetAliveMonsters()
-	}
-
 	// Poison
 	g.DoPostRoundPoison(aliveTeam1)
 	g.DoPostRoundPoison(aliveTeam2)
+
+	g.doAbilitiesPostRound(g.team1.GetAliveMonsters())
+	g.doAbilitiesPostRound(g.team2.GetAliveMonsters())
 }
 
 // handle earthquake
 func (g *Game) DoPostRoundEarthquake(monsters []*MonsterCard) {
 	for _, m := range monsters {
 		battleDamage := ApplyEarthquake(g, m)
+		g.recordDamageCalculation(BATTLE_ACTION_EARTHQUAKE, nil, battleDamage)
 		g.CreateAndAddBattleLog(BATTLE_ACTION_EARTHQUAKE, m, nil, battleDamage.DamageDone)
 		g.ProcessIfDead(m)
 		g.CheckAndSetGameWinner()
@@ -1259,9 +1255,12 @@ func (g *Game) DoPostRoundEarthquake(monsters []*MonsterCard) {
 func (g *Game) DoPostRoundPoison(monsters []*MonsterCard) {
 	for _, m := range monsters {
 		if m.HasDebuff(ABILITY_POISON) {
-			m.Health -= POISON_DAMAGE
+			poisonDamage := g.GetConfig().PoisonDamage
+			preHitHealth := m.Health
+			m.Health -= poisonDamage
+			g.emitHealthDamageEvent(BATTLE_ACTION_POISON, ATTACK_TYPE_NO_ATTACK, nil, m, preHitHealth-utils.GetBigger(m.Health, 0))
 			g.ProcessIfDead(m)
-			g.CreateAndAddBattleLog(BATTLE_ACTION_POISON, m, nil, POISON_DAMAGE)
+			g.CreateAndAddBattleLog(BATTLE_ACTION_POISON, m, nil, poisonDamage)
 		}
 		m.SetHasTurnPassed(false)
 	}
@@ -1297,6 +1296,7 @@ func (g *Game) ProcessIfResurrect(caster GameCardInterface, deadMonster *Monster
 		}
 		g.deadMonsters = deadMonsterList
 		g.CreateAndAddBattleLog(BATTLE_ACTION_RESURRECT, caster, deadMonster, 0)
+		g.emitEvent(BattleEvent{Type: BATTLE_EVENT_RESURRECT, Source: BATTLE_ACTION_RESURRECT, Actor: caster, Target: deadMonster})
 		return true
 	}
 
@@ -1329,11 +1329,8 @@ func (g *Game) RemoveMonsterBuff(m *MonsterCard, buffs []Ability, friendlyMonste
 
 // Handle scavenger and battle log
 func (g *Game) OnMonsterDeath(m *MonsterCard, deadMonster *MonsterCard) {
-	// Scavenger
-	if m.HasAbility(ABILITY_SCAVENGER) {
-		m.AddBuff(ABILITY_SCAVENGER)
-		g.CreateAndAddBattleLog(BATTLE_ACTION_SCAVENGER, m, deadMonster, 1)
-	}
+	// Scavenger, ...
+	g.doAbilitiesOnMonsterDeath(m, deadMonster)
 }
 
 func (g *Game) ActuallyHitMonster(attacker, target *MonsterCard, attackType CardAttackType) BattleDamage {
@@ -1349,5 +1346,95 @@ func (g *Game) ActuallyHitMonster(attacker, target *MonsterCard, attackType Card
 		battleDamage = HitMonsterWithPhysical(g, target, damageAmount)
 	}
 
+	if !g.isRecordingDamage() {
+		return battleDamage
+	}
+	if battleDamage.Calculation == nil {
+		battleDamage.Calculation = &DamageCalculation{Target: target, IncomingDamage: damageAmount}
+	}
+	g.fillAttackSideOfCalculation(battleDamage.Calculation, attacker, target, attackType)
+	g.recordDamageCalculation(GetAttackBattleAction(attackType), attacker, battleDamage)
 	return battleDamage
 }
+
+/** Fills the attack side (stats, summoner, buffs and multipliers) of the damage calculation */
+func (g *Game) fillAttackSideOfCalculation(calc *DamageCalculation, attacker, target *MonsterCard, attackType CardAttackType) {
+	multiplier, multiplierAbilities := g.GetDamageMultiplier(attacker, target)
+	calc.AttackType = attackType
+	calc.BaseAttack = attacker.GetBaseAttackOfType(attackType)
+	calc.SummonerBonus = attacker.GetSummonerAttackOfType(attackType)
+	calc.PostAbilityAttack = attacker.GetPostAbilityAttackOfType(attackType)
+	calc.ModifierBonus = calc.PostAbilityAttack - calc.BaseAttack - calc.SummonerBonus
+	calc.Modifiers = attacker.GetAttackModifierAbilities(attackType)
+	calc.Multiplier = multiplier
+	calc.MultiplierAbilities = multiplierAbilities
+}
+
+func (g *Game) recordBlastCalculation(attacker *MonsterCard, attackType CardAttackType, battleDamage BattleDamage, baseBlastDamage int, multiplier int, reductions []DamageReduction) {
+	calc := battleDamage.Calculation
+	if calc == nil {
+		return
+	}
+	calc.AttackType = attackType
+	calc.PostAbilityAttack = baseBlastDamage
+	calc.BaseAttack = baseBlastDamage
+	calc.Multiplier = multiplier
+	calc.IncomingDamage = baseBlastDamage * multiplier
+	calc.Reductions = append(reductions, calc.Reductions...)
+	g.recordDamageCalculation(BATTLE_ACTION_BLAST, attacker, battleDamage)
+}
+
+/** Damage calculations are only built when someone reads them: the game logs or has event listeners */
+func (g *Game) isRecordingDamage() bool {
+	return g != nil && (g.shouldLog || len(g.eventListeners) > 0)
+}
+
+/** Calculation of a hit on the target, nil if the game doesn't record damage */
+func (g *Game) newDamageCalculation(target *MonsterCard, attackType CardAttackType, incomingDamage int) *DamageCalculation {
+	if !g.isRecordingDamage() {
+		return nil
+	}
+	return &DamageCalculation{Target: target, AttackType: attackType, IncomingDamage: incomingDamage, Multiplier: 1}
+}
+
+/** Adds the side effect to the calculation (if any). The card is kept as it is now when the game logs, like the attacker and the target */
+func (g *Game) addDamageSideEffect(calc *DamageCalculation, ability Ability, card GameCardInterface, amount int) {
+	if calc == nil {
+		return
+	}
+	if g.shouldLog && card != nil {
+		card = card.Clone()
+	}
+	calc.AddSideEffect(ability, card, amount)
+}
+
+/** Emits the damage event of the hit and keeps its damage calculation when the game logs */
+func (g *Game) recordDamageCalculation(source AdditionalBattleAction, attacker GameCardInterface, battleDamage BattleDamage) {
+	calc := battleDamage.Calculation
+	if calc == nil {
+		return
+	}
+	calc.Round = g.roundNumber
+	calc.Source = source
+	calc.Attacker = attacker
+
+	if target, ok := calc.Target.(*MonsterCard); ok && calc.GetTotalDamage() > 0 {
+		if attacker != nil {
+			g.lastDamagedBy[target] = attacker
+		} else {
+			delete(g.lastDamagedBy, target)
+		}
+	}
+	g.emitEvent(BattleEvent{Type: BATTLE_EVENT_DAMAGE, Source: source, Actor: attacker, Target: calc.Target, Value: calc.GetTotalDamage(), Damage: calc})
+
+	if !g.shouldLog {
+		return
+	}
+	if attacker != nil {
+		calc.Attacker = attacker.Clone()
+	}
+	if calc.Target != nil {
+		calc.Target = calc.Target.Clone()
+	}
+	g.damageCalculations = append(g.damageCalculations, calc)
+}
@@ -2,8 +2,6 @@ package game_models
 
 import (
 	"log"
-	"math/rand"
-	"time"
 )
 
 type GameTeam struct {
@@ -44,6 +42,15 @@ func (t *GameTeam) GetPlayerName() string {
 	return t.playerName
 }
 
+/* Mana of the summoner and the monsters */
+func (t *GameTeam) GetManaCost() int {
+	mana := t.summoner.Mana
+	for _, m := range t.monsterList {
+		mana += m.Mana
+	}
+	return mana
+}
+
 func (t *GameTeam) SetMonsterPositions() {
 	for i := range t.monsterList {
 		t.monsterList[i].SetCardPosition(i)
@@ -118,16 +125,27 @@ func (t *GameTeam) SetAllMonsterHealth() {
 	}
 }
 
+/* Deprecated: not drawn from the random source of a game, use GetScattershotTargetFrom */
 func (t *GameTeam) GetScattershotTarget() *MonsterCard {
+	return t.GetScattershotTargetFrom(MathRandomSource{})
+}
+
+/* A random alive monster drawn from the random source */
+func (t *GameTeam) GetScattershotTargetFrom(source RandomSource) *MonsterCard {
 	aliveMonsters := t.GetAliveMonsters()
-	rand.Seed(time.Now().UnixNano())
-	randomMonsterNum := rand.Intn(len(aliveMonsters))
-	return aliveMonsters[randomMonsterNum]
+	if len(aliveMonsters) == 1 {
+		return aliveMonsters[0]
+	}
+	return aliveMonsters[source.Pick(RANDOM_EVENT_SCATTERSHOT, len(aliveMonsters))]
 }
 
 func (t *GameTeam) GetSnipeTarget() *MonsterCard {
-	tauntMonster := t.GetTauntMonster()
-	if tauntMonster != nil {
+	return t.getSnipeTarget(true)
+}
+
+/* useTaunt: the taunt monster is the target if there is one */
+func (t *GameTeam) getSnipeTarget(useTaunt bool) *MonsterCard {
+	if tauntMonster := t.GetTauntMonster(); useTaunt && tauntMonster != nil {
 		return tauntMonster
 	}
 	// no taunt monster
@@ -145,8 +163,12 @@ func (t *GameTeam) GetSnipeTarget() *MonsterCard {
 }
 
 func (t *GameTeam) GetOpportunityTarget() *MonsterCard {
-	tauntMonster := t.GetTauntMonster()
-	if tauntMonster != nil {
+	return t.getOpportunityTarget(true)
+}
+
+/* useTaunt: the taunt monster is the target if there is one */
+func (t *GameTeam) getOpportunityTarget(useTaunt bool) *MonsterCard {
+	if tauntMonster := t.GetTauntMonster(); useTaunt && tauntMonster != nil {
 		return tauntMonster
 	}
 
@@ -171,8 +193,12 @@ func (t *GameTeam) GetOpportunityTarget() *MonsterCard {
 }
 
 func (t *GameTeam) GetSneakTarget() *MonsterCard {
-	tauntMonster := t.GetTauntMonster()
-	if tauntMonster != nil {
+	return t.getSneakTarget(true)
+}
+
+/* useTaunt: the taunt monster is the target if there is one */
+func (t *GameTeam) getSneakTarget(useTaunt bool) *MonsterCard {
+	if tauntMonster := t.GetTauntMonster(); useTaunt && tauntMonster != nil {
 		return tauntMonster
 	}
 
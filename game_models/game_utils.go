@@ -2,12 +2,14 @@ package game_models
 
 import (
 	"fmt"
-	"math"
-	"math/rand"
-	"time"
 )
 
 func GetDodgeChance(rulesets []Ruleset, attacker *MonsterCard, target *MonsterCard, attackType CardAttackType) float64 {
+	return GetDodgeChanceWithConfig(DefaultEngineConfig(), rulesets, attacker, target, attackType)
+}
+
+/* Same as GetDodgeChance but uses the dodge chances of the config */
+func GetDodgeChanceWithConfig(config EngineConfig, rulesets []Ruleset, attacker *MonsterCard, target *MonsterCard, attackType CardAttackType) float64 {
 	if attacker == nil || target == nil || rulesets == nil || len(rulesets) == 0 {
 		return 0
 	}
@@ -34,28 +36,39 @@ func GetDodgeChance(rulesets []Ruleset, attacker *MonsterCard, target *MonsterCa
 	}
 	var dodgeChance float64 = 0
 	if speedDiff > 0 {
-		dodgeChance = float64(speedDiff) * SPEED_DIFF_DODGE_CHANCE
+		dodgeChance = float64(speedDiff) * config.SpeedDiffDodgeChance
 	}
 
 	// add dodge ability 25% chance to evade
 	if target.HasAbility(ABILITY_DODGE) {
-		dodgeChance = dodgeChance + DODGE_CHANCE
+		dodgeChance = dodgeChance + config.DodgeChance
 	}
 
 	// add flying ability 25% chance to evade (if attacker doesn't have flying and snare)
 	if target.HasAbility(ABILITY_FLYING) && !attacker.HasAbility(ABILITY_FLYING) && !target.HasDebuff(ABILITY_SNARE) {
-		dodgeChance = dodgeChance + FLYING_DODGE_CHANCE
+		dodgeChance = dodgeChance + config.FlyingDodgeChance
 	}
 
 	// +15% if attacker has blind
 	if attacker.HasDebuff(ABILITY_BLIND) {
-		dodgeChance = dodgeChance + BLIND_DODGE_CHANCE
+		dodgeChance = dodgeChance + config.BlindDodgeChance
+	}
+
+	// Aim True, ...
+	for _, h := range GetRulesetHandlers(rulesets) {
+		dodgeChance = h.ModifyDodgeChance(attacker, target, attackType, dodgeChance)
 	}
 	return dodgeChance
 }
 
+/* Deprecated: not drawn from the random source of a game, use Game.RollDodge */
 func GetDidDodge(rulesets []Ruleset, attacker *MonsterCard, target *MonsterCard, attackType CardAttackType) bool {
-	dodgeChance := GetDodgeChance(rulesets, attacker, target, attackType)
+	return GetDidDodgeWithConfig(DefaultEngineConfig(), rulesets, attacker, target, attackType)
+}
+
+/* Deprecated: not drawn from the random source of a game, use Game.RollDodge */
+func GetDidDodgeWithConfig(config EngineConfig, rulesets []Ruleset, attacker *MonsterCard, target *MonsterCard, attackType CardAttackType) bool {
+	dodgeChance := GetDodgeChanceWithConfig(config, rulesets, attacker, target, attackType)
 	if dodgeChance <= 0 {
 		return false
 	}
@@ -65,8 +78,9 @@ func GetDidDodge(rulesets []Ruleset, attacker *MonsterCard, target *MonsterCard,
 	return GetSuccessBelow(dodgeChance * 100)
 }
 
+/* Deprecated: not drawn from the random source of a game, use Game.RollSuccessBelow */
 func GetSuccessBelow(chance float64) bool {
-	return math.Floor(float64(rand.Intn(101))) < chance
+	return rollChance(MathRandomSource{}, "", GetSuccessBelowProbability(chance))
 }
 
 // Compare Attack Order
@@ -102,7 +116,12 @@ func NormalCompareAttackOrder(m1 *MonsterCard, m2 *MonsterCard) int {
 	return m1.GetLevel() - m2.GetLevel()
 }
 
+/* Deprecated: random ties are not drawn from the random source of a game, use Game.GetNextMonsterTurn */
 func ResolveFriendlyTies(m1 *MonsterCard, m2 *MonsterCard) int {
+	return resolveFriendlyTiesWith(m1, m2, RandomTieBreaker)
+}
+
+func resolveFriendlyTiesWith(m1 *MonsterCard, m2 *MonsterCard, tieBreaker func() int) int {
 	if m1 == nil || m2 == nil {
 		return 0
 	}
@@ -114,15 +133,12 @@ func ResolveFriendlyTies(m1 *MonsterCard, m2 *MonsterCard) int {
 		}
 		return 1
 	}
-	return RandomTieBreaker()
+	return tieBreaker()
 }
 
+/* Deprecated: not drawn from the random source of a game, use Game.RollTieBreaker */
 func RandomTieBreaker() int {
-	rand.Seed(time.Now().UnixNano())
-	if rand.Intn(100)+1 > 50 {
-		return -1
-	}
-	return 1
+	return rollTieBreaker(MathRandomSource{})
 }
 
 func CardsArrIncludesMonster(cards []*MonsterCard, m *MonsterCard) bool {
@@ -140,6 +156,8 @@ func CardsArrIncludesMonster(cards []*MonsterCard, m *MonsterCard) bool {
 }
 
 // https://support.splinterlands.com/hc/en-us/articles/4414334269460-Attack-Order
+//
+// Deprecated: random ties are not drawn from the random source of a game, use Game.GetNextMonsterTurn
 func MonsterTurnComparator(m1 *MonsterCard, m2 *MonsterCard) bool {
 
 	normalCompareDiff := NormalCompareAttackOrder(m1, m2)
@@ -157,6 +175,23 @@ func MonsterTurnComparator(m1 *MonsterCard, m2 *MonsterCard) bool {
 	}
 }
 
+/* Max level of a card of the rarity (1: common, 2: rare, 3: epic, 4: legendary). 0 if the rarity is unknown */
+func GetMaxLevelOfRarity(rarity int) int {
+	maxLevels := []int{10, 8, 6, 4}
+	if rarity < 1 || rarity > len(maxLevels) {
+		return 0
+	}
+	return maxLevels[rarity-1]
+}
+
+/* Max level of the card. Monsters have stats per level, so it's the number of levels in their stats */
+func GetMaxLevelOfCard(cardDetail CardDetail) int {
+	if levels, ok := cardDetail.Stats.Health.([]any); ok {
+		return len(levels)
+	}
+	return GetMaxLevelOfRarity(cardDetail.Rarity)
+}
+
 func PrintMonsterListPointer(label string, array []*MonsterCard) {
 	fmt.Printf("\n\n%s, length: %d\n", label, len(array))
 	for _, item := range array {
@@ -0,0 +1,111 @@
+package game_models
+
+import "sort"
+
+/* A monster that was played at a lower level because of the summoner level cap */
+type LevelCapAdjustment struct {
+	Position     int
+	CardDetailID int
+	CardName     string
+	Level        int
+	CappedLevel  int
+}
+
+/* Returns the team with every monster level capped by the summoner level, and the monsters that were capped down */
+func ApplySummonerLevelCap(cardDetailMap CardDetailMap, battleTeam BattleTeam) (BattleTeam, []LevelCapAdjustment) {
+	adjustments := make([]LevelCapAdjustment, 0)
+	summonerDetail := cardDetailMap[battleTeam.Summoner.CardDetailID]
+
+	cappedTeam := battleTeam
+	cappedTeam.Monsters = make([]CollectionCard, len(battleTeam.Monsters))
+	copy(cappedTeam.Monsters, battleTeam.Monsters)
+	for i, m := range cappedTeam.Monsters {
+		mDetail := cardDetailMap[m.CardDetailID]
+		levelCap := GetMonsterLevelCap(summonerDetail.Rarity, battleTeam.Summoner.Level, mDetail.Rarity)
+		if levelCap <= 0 || m.Level <= levelCap {
+			continue
+		}
+		adjustments = append(adjustments, LevelCapAdjustment{
+			Position:     i,
+			CardDetailID: m.CardDetailID,
+			CardName:     mDetail.Name,
+			Level:        m.Level,
+			CappedLevel:  levelCap,
+		})
+		cappedTeam.Monsters[i].Level = levelCap
+	}
+	return cappedTeam, adjustments
+}
+
+/* Returns the team with the summoner and every monster level capped by the rating level of the match, and the cards that were capped down */
+func ApplyMatchLevelCap(cardDetailMap CardDetailMap, battleTeam BattleTeam, settings MatchSettings) (BattleTeam, []LevelCapAdjustment) {
+	adjustments := make([]LevelCapAdjustment, 0)
+	capCard := func(position int, card *CollectionCard) {
+		cardDetail := cardDetailMap[card.CardDetailID]
+		levelCap := settings.GetLevelCap(cardDetail.Rarity)
+		if levelCap <= 0 || card.Level <= levelCap {
+			return
+		}
+		adjustments = append(adjustments, LevelCapAdjustment{
+			Position:     position,
+			CardDetailID: card.CardDetailID,
+			CardName:     cardDetail.Name,
+			Level:        card.Level,
+			CappedLevel:  levelCap,
+		})
+		card.Level = levelCap
+	}
+
+	cappedTeam := battleTeam
+	cappedTeam.Monsters = make([]CollectionCard, len(battleTeam.Monsters))
+	copy(cappedTeam.Monsters, battleTeam.Monsters)
+	capCard(SUMMONER_POSITION, &cappedTeam.Summoner)
+	for i := range cappedTeam.Monsters {
+		capCard(i, &cappedTeam.Monsters[i])
+	}
+	return cappedTeam, adjustments
+}
+
+/* Cards of both teams of a battle played at a lower level because of the level caps */
+type BattleLevelCaps struct {
+	Team1 []LevelCapAdjustment
+	Team2 []LevelCapAdjustment
+}
+
+/*
+Returns the battle with both teams capped by the rating level of the match, then the monsters capped by the summoner level,
+and the cards that were capped down. A card capped by both is reported once with its final level.
+*/
+func ApplyBattleLevelCaps(cardDetailMap CardDetailMap, battleDetails BattleDetails, settings MatchSettings) (BattleDetails, BattleLevelCaps) {
+	var caps BattleLevelCaps
+	battleDetails.Team1, caps.Team1 = ApplyTeamLevelCaps(cardDetailMap, battleDetails.Team1, settings)
+	battleDetails.Team2, caps.Team2 = ApplyTeamLevelCaps(cardDetailMap, battleDetails.Team2, settings)
+	return battleDetails, caps
+}
+
+/* Same as ApplyBattleLevelCaps with a single team */
+func ApplyTeamLevelCaps(cardDetailMap CardDetailMap, battleTeam BattleTeam, settings MatchSettings) (BattleTeam, []LevelCapAdjustment) {
+	matchCapped, matchAdjustments := ApplyMatchLevelCap(cardDetailMap, battleTeam, settings)
+	capped, summonerAdjustments := ApplySummonerLevelCap(cardDetailMap, matchCapped)
+	return capped, mergeLevelCapAdjustments(matchAdjustments, summonerAdjustments)
+}
+
+func mergeLevelCapAdjustments(first []LevelCapAdjustment, second []LevelCapAdjustment) []LevelCapAdjustment {
+	merged := append(make([]LevelCapAdjustment, 0, len(first)+len(second)), first...)
+	for _, adjustment := range second {
+		isMerged := false
+		for i := range merged {
+			if merged[i].Position == adjustment.Position {
+				merged[i].CappedLevel = adjustment.CappedLevel
+				isMerged = true
+			}
+		}
+		if !isMerged {
+			merged = append(merged, adjustment)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Position < merged[j].Position
+	})
+	return merged
+}
@@ -0,0 +1,106 @@
+package game_models
+
+import (
+	"fmt"
+
+	utils "github.com/YukiUmetsu/go-spl-simulator/game_utils"
+)
+
+const (
+	ALLOWED_FOIL_ALL       = "all"
+	ALLOWED_FOIL_GOLD_ONLY = "gold_only"
+
+	ALLOWED_TYPE_ALL            = "all"
+	ALLOWED_TYPE_NO_LEGENDARIES = "no_legendaries"
+)
+
+/* League of the rating level of a match */
+const (
+	RATING_LEVEL_NOVICE = iota
+	RATING_LEVEL_BRONZE
+	RATING_LEVEL_SILVER
+	RATING_LEVEL_GOLD
+	RATING_LEVEL_DIAMOND
+	RATING_LEVEL_CHAMPION
+)
+
+/* Max card level of each rarity (common, rare, epic, legendary) per rating level */
+var RATING_LEVEL_CAPS = [][]int{
+	{1, 1, 1, 1},
+	{3, 2, 2, 1},
+	{5, 4, 3, 2},
+	{8, 6, 4, 3},
+	{10, 8, 5, 4},
+	{10, 8, 6, 4},
+}
+
+/* Restrictions of a match (ranked, brawl or tournament) */
+type MatchSettings struct {
+	// -1 if the cards are not capped
+	RatingLevel    int
+	AllowedFoil    string
+	AllowedType    string
+	Format         CardFormat
+	InactiveColors []CardColor
+}
+
+/* Settings without any restriction */
+func NewMatchSettings() MatchSettings {
+	return MatchSettings{RatingLevel: -1, AllowedFoil: ALLOWED_FOIL_ALL, AllowedType: ALLOWED_TYPE_ALL, Format: NewWildFormat(), InactiveColors: make([]CardColor, 0)}
+}
+
+/* Max level of a card of the rarity in the match, 0 if not capped */
+func (s MatchSettings) GetLevelCap(rarity int) int {
+	if s.RatingLevel < 0 || s.RatingLevel >= len(RATING_LEVEL_CAPS) || rarity < 1 || rarity > len(RATING_LEVEL_CAPS[s.RatingLevel]) {
+		return 0
+	}
+	return RATING_LEVEL_CAPS[s.RatingLevel][rarity-1]
+}
+
+func (s MatchSettings) IsColorActive(color CardColor) bool {
+	return color == COLOR_GRAY || !utils.Contains(s.InactiveColors, color)
+}
+
+/* Tells if the card can be played in the match. The level is not checked, cards over the level cap are played at the cap. */
+func (s MatchSettings) IsCardAllowed(cardDetail CardDetail, card CollectionCard) bool {
+	return s.getRestrictionReason(cardDetail, card) == ""
+}
+
+func (s MatchSettings) getRestrictionReason(cardDetail CardDetail, card CollectionCard) string {
+	switch {
+	case !s.IsColorActive(cardDetail.Color):
+		return "is of an inactive splinter"
+	case s.AllowedFoil == ALLOWED_FOIL_GOLD_ONLY && !card.Gold:
+		return "is not gold foil"
+	case s.AllowedType == ALLOWED_TYPE_NO_LEGENDARIES && cardDetail.Rarity == 4:
+		return "is legendary"
+	case !s.Format.IsCardLegal(cardDetail):
+		return "is not legal in " + s.Format.Name
+	}
+	return ""
+}
+
+/* A card of the team that can't be played in the match */
+type MatchRestrictionError struct {
+	CardDetailID int
+	CardName     string
+	Reason       string
+}
+
+func (e *MatchRestrictionError) Error() string {
+	return fmt.Sprintf("%s (%d) %s", e.CardName, e.CardDetailID, e.Reason)
+}
+
+/* Cards of the team that can't be played in the match */
+func (s MatchSettings) ValidateBattleTeam(cardDetailMap CardDetailMap, battleTeam BattleTeam) []error {
+	errs := make([]error, 0)
+	cards := append([]CollectionCard{battleTeam.Summoner}, battleTeam.Monsters...)
+	for _, card := range cards {
+		cardDetail := cardDetailMap[card.CardDetailID]
+		reason := s.getRestrictionReason(cardDetail, card)
+		if reason != "" {
+			errs = append(errs, &MatchRestrictionError{CardDetailID: card.CardDetailID, CardName: cardDetail.Name, Reason: reason})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,303 @@
+package game_models
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+/* Upper mana cap of each mana bracket but the last one */
+var MANA_BRACKET_BOUNDS = []int{20, 30, 45}
+
+/* Name of the mana bracket of the mana cap, e.g. "21-30" or "46+" */
+func GetManaBracket(manaCap int) string {
+	lower := 0
+	for _, upper := range MANA_BRACKET_BOUNDS {
+		if manaCap <= upper {
+			return fmt.Sprintf("%d-%d", lower, upper)
+		}
+		lower = upper + 1
+	}
+	return fmt.Sprintf("%d+", lower)
+}
+
+func getManaBrackets() []string {
+	brackets := make([]string, 0, len(MANA_BRACKET_BOUNDS)+1)
+	lower := 0
+	for _, upper := range MANA_BRACKET_BOUNDS {
+		brackets = append(brackets, GetManaBracket(upper))
+		lower = upper + 1
+	}
+	return append(brackets, GetManaBracket(lower))
+}
+
+/* A team of a battle of the archive */
+type MetaTeam struct {
+	Team BattleTeam
+	// rating of the player before the battle
+	Rating int
+	Won    bool
+}
+
+type MetaBattle struct {
+	ManaCap  int
+	Rulesets []Ruleset
+	Teams    []MetaTeam
+}
+
+/* How a card was played. Ties count as losses. */
+type CardMetaStats struct {
+	CardDetailID int    `json:"card_detail_id"`
+	Name         string `json:"name"`
+	// teams the card was played in
+	Played  int     `json:"played"`
+	Wins    int     `json:"wins"`
+	Winrate float64 `json:"winrate"`
+	// share of the teams the card was played in
+	PlayRate      float64 `json:"play_rate"`
+	AverageRating float64 `json:"average_rating"`
+}
+
+/* Two monsters played in the same team */
+type PairingMetaStats struct {
+	CardDetailIDs [2]int    `json:"card_detail_ids"`
+	Names         [2]string `json:"names"`
+	Played        int       `json:"played"`
+	Wins          int       `json:"wins"`
+	Winrate       float64   `json:"winrate"`
+	PlayRate      float64   `json:"play_rate"`
+	AverageRating float64   `json:"average_rating"`
+}
+
+/* Statistics of the battles of a ruleset and mana bracket ("" for every ruleset or every mana bracket), most played first */
+type MetaGroup struct {
+	Ruleset     Ruleset            `json:"ruleset"`
+	ManaBracket string             `json:"mana_bracket"`
+	Battles     int                `json:"battles"`
+	Teams       int                `json:"teams"`
+	Summoners   []CardMetaStats    `json:"summoners"`
+	Monsters    []CardMetaStats    `json:"monsters"`
+	Pairings    []PairingMetaStats `json:"pairings"`
+}
+
+type MetaReport struct {
+	Groups []MetaGroup `json:"groups"`
+}
+
+type metaGroupKey struct {
+	ruleset     Ruleset
+	manaBracket string
+}
+
+type metaCounter struct {
+	played    int
+	wins      int
+	ratingSum int
+}
+
+func (c *metaCounter) add(team MetaTeam) {
+	c.played++
+	c.ratingSum += team.Rating
+	if team.Won {
+		c.wins++
+	}
+}
+
+type metaGroupStats struct {
+	battles   int
+	teams     int
+	summoners map[int]*metaCounter
+	monsters  map[int]*metaCounter
+	pairings  map[[2]int]*metaCounter
+}
+
+/* Aggregates battles into a MetaReport */
+type MetaStats struct {
+	cardDetailMap CardDetailMap
+	groups        map[metaGroupKey]*metaGroupStats
+}
+
+func NewMetaStats(cardDetailMap CardDetailMap) *MetaStats {
+	return &MetaStats{cardDetailMap: cardDetailMap, groups: make(map[metaGroupKey]*metaGroupStats)}
+}
+
+/* Counts the battle in every group it belongs to: every battle, its rulesets, its mana bracket and both combined */
+func (s *MetaStats) AddBattle(battle MetaBattle) {
+	bracket := GetManaBracket(battle.ManaCap)
+	keys := []metaGroupKey{{"", ""}, {"", bracket}}
+	for _, ruleset := range battle.Rulesets {
+		keys = append(keys, metaGroupKey{ruleset, ""}, metaGroupKey{ruleset, bracket})
+	}
+
+	for _, key := range keys {
+		group := s.getGroup(key)
+		group.battles++
+		for _, team := range battle.Teams {
+			group.teams++
+			getMetaCounter(group.summoners, team.Team.Summoner.CardDetailID).add(team)
+			for i, m := range team.Team.Monsters {
+				getMetaCounter(group.monsters, m.CardDetailID).add(team)
+				for _, other := range team.Team.Monsters[i+1:] {
+					pair := [2]int{m.CardDetailID, other.CardDetailID}
+					if pair[0] > pair[1] {
+						pair[0], pair[1] = pair[1], pair[0]
+					}
+					getMetaCounter(group.pairings, pair).add(team)
+				}
+			}
+		}
+	}
+}
+
+func (s *MetaStats) getGroup(key metaGroupKey) *metaGroupStats {
+	if _, ok := s.groups[key]; !ok {
+		s.groups[key] = &metaGroupStats{
+			summoners: make(map[int]*metaCounter),
+			monsters:  make(map[int]*metaCounter),
+			pairings:  make(map[[2]int]*metaCounter),
+		}
+	}
+	return s.groups[key]
+}
+
+func getMetaCounter[K comparable](counters map[K]*metaCounter, key K) *metaCounter {
+	if _, ok := counters[key]; !ok {
+		counters[key] = &metaCounter{}
+	}
+	return counters[key]
+}
+
+/* Groups sorted by ruleset then mana bracket, every ruleset and every mana bracket first */
+func (s *MetaStats) GetReport() MetaReport {
+	bracketOrder := map[string]int{"": 0}
+	for i, bracket := range getManaBrackets() {
+		bracketOrder[bracket] = i + 1
+	}
+
+	report := MetaReport{Groups: make([]MetaGroup, 0, len(s.groups))}
+	for key, stats := range s.groups {
+		group := MetaGroup{
+			Ruleset:     key.ruleset,
+			ManaBracket: key.manaBracket,
+			Battles:     stats.battles,
+			Teams:       stats.teams,
+			Summoners:   s.getCardMetaStats(stats.summoners, stats.teams),
+			Monsters:    s.getCardMetaStats(stats.monsters, stats.teams),
+			Pairings:    make([]PairingMetaStats, 0, len(stats.pairings)),
+		}
+		for pair, c := range stats.pairings {
+			winrate, playRate, averageRating := c.getRates(stats.teams)
+			group.Pairings = append(group.Pairings, PairingMetaStats{
+				CardDetailIDs: pair,
+				Names:         [2]string{s.cardDetailMap[pair[0]].Name, s.cardDetailMap[pair[1]].Name},
+				Played:        c.played,
+				Wins:          c.wins,
+				Winrate:       winrate,
+				PlayRate:      playRate,
+				AverageRating: averageRating,
+			})
+		}
+		sort.SliceStable(group.Pairings, func(i, j int) bool {
+			a, b := group.Pairings[i], group.Pairings[j]
+			if a.Played != b.Played {
+				return a.Played > b.Played
+			}
+			return a.CardDetailIDs[0] < b.CardDetailIDs[0] || (a.CardDetailIDs[0] == b.CardDetailIDs[0] && a.CardDetailIDs[1] < b.CardDetailIDs[1])
+		})
+		report.Groups = append(report.Groups, group)
+	}
+
+	sort.SliceStable(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if a.Ruleset != b.Ruleset {
+			return a.Ruleset < b.Ruleset
+		}
+		return bracketOrder[a.ManaBracket] < bracketOrder[b.ManaBracket]
+	})
+	return report
+}
+
+func (c *metaCounter) getRates(teams int) (float64, float64, float64) {
+	if c.played == 0 || teams == 0 {
+		return 0, 0, 0
+	}
+	played := float64(c.played)
+	return float64(c.wins) / played, played / float64(teams), float64(c.ratingSum) / played
+}
+
+func (s *MetaStats) getCardMetaStats(counters map[int]*metaCounter, teams int) []CardMetaStats {
+	stats := make([]CardMetaStats, 0, len(counters))
+	for id, c := range counters {
+		winrate, playRate, averageRating := c.getRates(teams)
+		stats = append(stats, CardMetaStats{
+			CardDetailID:  id,
+			Name:          s.cardDetailMap[id].Name,
+			Played:        c.played,
+			Wins:          c.wins,
+			Winrate:       winrate,
+			PlayRate:      playRate,
+			AverageRating: averageRating,
+		})
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].Played != stats[j].Played {
+			return stats[i].Played > stats[j].Played
+		}
+		return stats[i].CardDetailID < stats[j].CardDetailID
+	})
+	return stats
+}
+
+/* Group of the ruleset and mana bracket ("" for every ruleset or every mana bracket) */
+func (r MetaReport) GetGroup(ruleset Ruleset, manaBracket string) (MetaGroup, bool) {
+	for _, group := range r.Groups {
+		if group.Ruleset == ruleset && group.ManaBracket == manaBracket {
+			return group, true
+		}
+	}
+	return MetaGroup{}, false
+}
+
+func (r MetaReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+/* Writes a row per summoner, monster and pairing of every group */
+func (r MetaReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	header := []string{"ruleset", "mana_bracket", "kind", "card_detail_id", "name", "paired_card_detail_id", "paired_name", "played", "wins", "winrate", "play_rate", "average_rating"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', 4, 64)
+	}
+	for _, group := range r.Groups {
+		row := func(kind string, id int, name string, pairedID string, pairedName string, played, wins int, winrate, playRate, averageRating float64) []string {
+			return []string{
+				string(group.Ruleset), group.ManaBracket, kind, strconv.Itoa(id), name, pairedID, pairedName,
+				strconv.Itoa(played), strconv.Itoa(wins), formatFloat(winrate), formatFloat(playRate), formatFloat(averageRating),
+			}
+		}
+		rows := make([][]string, 0)
+		for _, c := range group.Summoners {
+			rows = append(rows, row("summoner", c.CardDetailID, c.Name, "", "", c.Played, c.Wins, c.Winrate, c.PlayRate, c.AverageRating))
+		}
+		for _, c := range group.Monsters {
+			rows = append(rows, row("monster", c.CardDetailID, c.Name, "", "", c.Played, c.Wins, c.Winrate, c.PlayRate, c.AverageRating))
+		}
+		for _, p := range group.Pairings {
+			rows = append(rows, row("pairing", p.CardDetailIDs[0], p.Names[0], strconv.Itoa(p.CardDetailIDs[1]), p.Names[1], p.Played, p.Wins, p.Winrate, p.PlayRate, p.AverageRating))
+		}
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
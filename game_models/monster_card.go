@@ -36,103 +36,31 @@ type MonsterCard struct {
 	summonerRanged  int
 	summonerMagic   int
 	hadDivineShield bool
+	// handlers replaced in the game of the card, for the stat modifiers
+	abilityHandlerOverrides map[Ability]AbilityHandler
 }
 
-func (c *MonsterCard) Setup(cardDetail CardDetail, cardLevel int) {
+/*
+Sets the card up from its detail. Returns a *CardDecodeError (with the card id) if the card is malformed,
+the card then has no stats. A level out of the card's levels is reported too and clamped to the closest level.
+*/
+func (c *MonsterCard) Setup(cardDetail CardDetail, cardLevel int) error {
 	c.cardDetail = cardDetail
 	c.CardLevel = cardLevel
-	var cardStatsByLevel CardStatsByLevel
-
-	// convert interface to ability
-	abilityByLevel := make([][]Ability, 0)
-	for _, abilityArr := range cardDetail.Stats.Abilities {
-		abilitiesInLevel := []Ability{}
-		for _, ability := range abilityArr.([]any) {
-			abilitiesInLevel = append(abilitiesInLevel, Ability(ability.(string)))
-		}
-		abilityByLevel = append(abilityByLevel, abilitiesInLevel)
-	}
-	cardStatsByLevel.Abilities = abilityByLevel
-
-	// convert interface to []int
-	manaByLevel := make([]int, 0)
-	for _, m := range cardDetail.Stats.Mana.([]any) {
-		switch m.(type) {
-		case float64:
-			manaByLevel = append(manaByLevel, int(m.(float64)))
-		default:
-			manaByLevel = append(manaByLevel, m.(int))
-		}
-	}
-	cardStatsByLevel.Mana = manaByLevel
 
-	atkByLevel := make([]int, 0)
-	for _, m := range cardDetail.Stats.Attack.([]any) {
-		switch m.(type) {
-		case float64:
-			atkByLevel = append(atkByLevel, int(m.(float64)))
-		default:
-			atkByLevel = append(atkByLevel, m.(int))
-		}
+	decoded, err := DecodeCardStats(cardDetail)
+	if err == nil && decoded.IsSummoner {
+		err = &CardDecodeError{CardDetailID: cardDetail.ID, Name: cardDetail.Name, Field: "health", Reason: "expected a monster with stats per level"}
 	}
-	cardStatsByLevel.Attack = atkByLevel
-
-	rangeByLevel := make([]int, 0)
-	for _, m := range cardDetail.Stats.Ranged.([]any) {
-		switch m.(type) {
-		case float64:
-			rangeByLevel = append(rangeByLevel, int(m.(float64)))
-		default:
-			rangeByLevel = append(rangeByLevel, m.(int))
-		}
+	if err != nil {
+		return err
 	}
-	cardStatsByLevel.Ranged = rangeByLevel
-
-	magicByLevel := make([]int, 0)
-	for _, m := range cardDetail.Stats.Magic.([]any) {
-		switch m.(type) {
-		case float64:
-			magicByLevel = append(magicByLevel, int(m.(float64)))
-		default:
-			magicByLevel = append(magicByLevel, m.(int))
-		}
-	}
-	cardStatsByLevel.Magic = magicByLevel
-
-	armorByLevel := make([]int, 0)
-	for _, m := range cardDetail.Stats.Armor.([]any) {
-		switch m.(type) {
-		case float64:
-			armorByLevel = append(armorByLevel, int(m.(float64)))
-		default:
-			armorByLevel = append(armorByLevel, m.(int))
-		}
+	if cardLevel < 1 || cardLevel > decoded.GetLevelCount() {
+		err = &CardDecodeError{CardDetailID: cardDetail.ID, Name: cardDetail.Name, Field: "level", Reason: fmt.Sprintf("level %d is not between 1 and %d", cardLevel, decoded.GetLevelCount())}
+		c.CardLevel = utils.GetBigger(1, utils.GetSmaller(cardLevel, decoded.GetLevelCount()))
 	}
-	cardStatsByLevel.Armor = armorByLevel
-
-	speedByLevel := make([]int, 0)
-	for _, m := range cardDetail.Stats.Speed.([]any) {
-		switch m.(type) {
-		case float64:
-			speedByLevel = append(speedByLevel, int(m.(float64)))
-		default:
-			speedByLevel = append(speedByLevel, m.(int))
-		}
-	}
-	cardStatsByLevel.Speed = speedByLevel
-
-	hpByLevel := make([]int, 0)
-	for _, m := range cardDetail.Stats.Health.([]any) {
-		switch m.(type) {
-		case float64:
-			hpByLevel = append(hpByLevel, int(m.(float64)))
-		default:
-			hpByLevel = append(hpByLevel, m.(int))
-		}
-	}
-	cardStatsByLevel.Health = hpByLevel
-
-	c.SetStats(cardStatsByLevel)
+	c.SetStats(decoded.MonsterStats)
+	return err
 }
 
 func (c *MonsterCard) SetTeam(teamNumber TeamNumber) {
@@ -145,7 +73,9 @@ func (c *MonsterCard) GetCardLevel() int {
 
 func (c *MonsterCard) GetCleanCard() *MonsterCard {
 	var monster *MonsterCard = &MonsterCard{}
-	monster.Setup(c.cardDetail, c.GetCardLevel())
+	// the card was set up from the same detail and level before
+	_ = monster.Setup(c.cardDetail, c.GetCardLevel())
+	monster.abilityHandlerOverrides = c.abilityHandlerOverrides
 	return monster
 }
 
@@ -489,7 +419,7 @@ func (c *MonsterCard) GetPostAbilityMaxHealth() int {
 		maxHealth = maxHealth + c.StartingHealth - 1
 	}
 
-	return utils.GetBigger(maxHealth, 1)
+	return utils.GetBigger(c.applyAbilityStatModifiers(STAT_HEALTH, maxHealth), 1)
 }
 
 func (c *MonsterCard) GetPostAbilityAttackOfType(attackType CardAttackType) int {
@@ -530,7 +460,7 @@ func (c *MonsterCard) GetPostAbilityMagic() int {
 		magicModifier = magicModifier + c.summonerMagic
 	}
 
-	return utils.GetBigger(postMagic+magicModifier, 1)
+	return utils.GetBigger(c.applyAbilityStatModifiers(STAT_MAGIC, postMagic+magicModifier), 1)
 }
 
 /**  How much range damage this will do */
@@ -558,7 +488,7 @@ func (c *MonsterCard) GetPostAbilityRange() int {
 		rangeModifier = rangeModifier + c.summonerRanged
 	}
 
-	return utils.GetBigger(postRange+rangeModifier, 1)
+	return utils.GetBigger(c.applyAbilityStatModifiers(STAT_RANGED, postRange+rangeModifier), 1)
 }
 
 /**  How much melee damage this will do */
@@ -589,7 +519,7 @@ func (c *MonsterCard) GetPostAbilityMelee() int {
 		meleeModifier = meleeModifier + c.summonerMelee
 	}
 
-	currentMelee := utils.GetBigger(postMelee+meleeModifier, 1)
+	currentMelee := utils.GetBigger(c.applyAbilityStatModifiers(STAT_ATTACK, postMelee+meleeModifier), 1)
 	if c.IsEnraged() {
 		return int(math.Ceil(float64(currentMelee) * ENRAGE_MULTIPLIER))
 	}
@@ -597,6 +527,66 @@ func (c *MonsterCard) GetPostAbilityMelee() int {
 	return currentMelee
 }
 
+/** Attack stat of the card itself for the attack type, before any summoner or ability modifier */
+func (c *MonsterCard) GetBaseAttackOfType(attackType CardAttackType) int {
+	if attackType == ATTACK_TYPE_MAGIC {
+		return c.Magic
+	}
+	if attackType == ATTACK_TYPE_RANGED {
+		return c.Ranged
+	}
+	if attackType == ATTACK_TYPE_MELEE {
+		return c.Melee
+	}
+	return 0
+}
+
+/** Attack added (or removed) by the summoners for the attack type */
+func (c *MonsterCard) GetSummonerAttackOfType(attackType CardAttackType) int {
+	if attackType == ATTACK_TYPE_MAGIC {
+		return c.summonerMagic
+	}
+	if attackType == ATTACK_TYPE_RANGED {
+		return c.summonerRanged
+	}
+	if attackType == ATTACK_TYPE_MELEE {
+		return c.summonerMelee
+	}
+	return 0
+}
+
+/** Buffs, debuffs and abilities that currently change the attack of the type */
+func (c *MonsterCard) GetAttackModifierAbilities(attackType CardAttackType) []Ability {
+	modifiers := []Ability{}
+	if c.GetBaseAttackOfType(attackType) == 0 {
+		return modifiers
+	}
+	if c.HasDebuff(ABILITY_HALVING) {
+		modifiers = append(modifiers, ABILITY_HALVING)
+	}
+	if c.GetIsLastStand() {
+		modifiers = append(modifiers, ABILITY_LAST_STAND)
+	}
+	if attackType == ATTACK_TYPE_MAGIC && c.HasDebuff(ABILITY_SILENCE) {
+		modifiers = append(modifiers, ABILITY_SILENCE)
+	}
+	if attackType == ATTACK_TYPE_RANGED && c.HasDebuff(ABILITY_HEADWINDS) {
+		modifiers = append(modifiers, ABILITY_HEADWINDS)
+	}
+	if attackType == ATTACK_TYPE_MELEE {
+		if c.HasDebuff(ABILITY_DEMORALIZE) {
+			modifiers = append(modifiers, ABILITY_DEMORALIZE)
+		}
+		if c.HasBuff(ABILITY_INSPIRE) {
+			modifiers = append(modifiers, ABILITY_INSPIRE)
+		}
+		if c.IsEnraged() {
+			modifiers = append(modifiers, ABILITY_ENRAGE)
+		}
+	}
+	return modifiers
+}
+
 func (c *MonsterCard) GetPostAbilitySpeed() int {
 	speedModifier := 0
 	speed := c.Speed + c.summonerSpeed
@@ -612,7 +602,7 @@ func (c *MonsterCard) GetPostAbilitySpeed() int {
 	if c.HasDebuff(ABILITY_SLOW) {
 		speedModifier = speedModifier - c.GetDebuffCount(ABILITY_SLOW)
 	}
-	return utils.GetBigger(speed+speedModifier, 1)
+	return utils.GetBigger(c.applyAbilityStatModifiers(STAT_SPEED, speed+speedModifier), 1)
 }
 
 func (c *MonsterCard) GetPostAbilityMaxArmor() int {
@@ -632,7 +622,7 @@ func (c *MonsterCard) GetPostAbilityMaxArmor() int {
 		armorModifier = armorModifier - RUST_AMOUNT
 	}
 
-	return utils.GetBigger(postArmor+armorModifier, 0)
+	return utils.GetBigger(c.applyAbilityStatModifiers(STAT_ARMOR, postArmor+armorModifier), 0)
 }
 
 func (c *MonsterCard) RemoveBuff(buff Ability) {
@@ -0,0 +1,230 @@
+package game_models
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// a battle with a mana cap this far from the match counts e^-1 as much
+	PROFILE_MANA_CAP_SCALE = 5.0
+	// a battle counts 1 + PROFILE_RULESET_BONUS times as much for each ruleset it shares with the match
+	PROFILE_RULESET_BONUS = 1.0
+	// a battle this many battles older counts half as much
+	PROFILE_RECENCY_HALF_LIFE = 20.0
+)
+
+/* A team a player played in a battle */
+type ProfiledBattle struct {
+	CreatedDate string
+	ManaCap     int
+	Rulesets    []Ruleset
+	// splinter the team was played in
+	Color CardColor
+	Team  BattleTeam
+	Won   bool
+}
+
+/* How often a card was played, Share is the share of the battles */
+type CardUsage struct {
+	CardDetailID int
+	Count        int
+	Share        float64
+	// times a monster was played at each position, always 0 for summoners
+	Positions []int
+}
+
+type ColorUsage struct {
+	Color CardColor
+	Count int
+	Share float64
+}
+
+/* Favorite cards and splinters of a player over a set of battles, most played first */
+type ProfileSummary struct {
+	Battles   int
+	Winrate   float64
+	Summoners []CardUsage
+	Monsters  []CardUsage
+	Splinters []ColorUsage
+}
+
+/* A team a player is likely to play next */
+type TeamProbability struct {
+	Team        BattleTeam
+	Probability float64
+	// battles the team was played in
+	Battles int
+}
+
+/* Teams a player played, oldest first */
+type OpponentProfile struct {
+	Player  string
+	Battles []ProfiledBattle
+}
+
+func NewOpponentProfile(player string) OpponentProfile {
+	return OpponentProfile{Player: player, Battles: make([]ProfiledBattle, 0)}
+}
+
+/* Adds a battle after the battles of the same date or older (RFC3339 dates sort as strings) */
+func (p *OpponentProfile) AddBattle(battle ProfiledBattle) {
+	i := sort.Search(len(p.Battles), func(i int) bool {
+		return p.Battles[i].CreatedDate > battle.CreatedDate
+	})
+	p.Battles = append(p.Battles, ProfiledBattle{})
+	copy(p.Battles[i+1:], p.Battles[i:])
+	p.Battles[i] = battle
+}
+
+/* Same as AddBattle with many battles, sorted once */
+func (p *OpponentProfile) AddBattles(battles []ProfiledBattle) {
+	p.Battles = append(p.Battles, battles...)
+	sort.SliceStable(p.Battles, func(i, j int) bool {
+		return p.Battles[i].CreatedDate < p.Battles[j].CreatedDate
+	})
+}
+
+/* Summary of the battles matching the filter (every battle if nil) */
+func (p OpponentProfile) Summarize(filter func(battle ProfiledBattle) bool) ProfileSummary {
+	summary := ProfileSummary{Summoners: make([]CardUsage, 0), Monsters: make([]CardUsage, 0), Splinters: make([]ColorUsage, 0)}
+	summoners := make(map[int]*CardUsage)
+	monsters := make(map[int]*CardUsage)
+	splinters := make(map[CardColor]*ColorUsage)
+	wins := 0
+	getUsage := func(usages map[int]*CardUsage, cardDetailID int) *CardUsage {
+		if _, ok := usages[cardDetailID]; !ok {
+			usages[cardDetailID] = &CardUsage{CardDetailID: cardDetailID, Positions: make([]int, MAX_TEAM_MONSTERS)}
+		}
+		return usages[cardDetailID]
+	}
+
+	for _, battle := range p.Battles {
+		if filter != nil && !filter(battle) {
+			continue
+		}
+		summary.Battles++
+		if battle.Won {
+			wins++
+		}
+		getUsage(summoners, battle.Team.Summoner.CardDetailID).Count++
+		for position, m := range battle.Team.Monsters {
+			usage := getUsage(monsters, m.CardDetailID)
+			usage.Count++
+			if position < MAX_TEAM_MONSTERS {
+				usage.Positions[position]++
+			}
+		}
+		if _, ok := splinters[battle.Color]; !ok {
+			splinters[battle.Color] = &ColorUsage{Color: battle.Color}
+		}
+		splinters[battle.Color].Count++
+	}
+	if summary.Battles == 0 {
+		return summary
+	}
+
+	battles := float64(summary.Battles)
+	summary.Winrate = float64(wins) / battles
+	summary.Summoners = getSortedCardUsages(summoners, battles)
+	summary.Monsters = getSortedCardUsages(monsters, battles)
+	for _, usage := range splinters {
+		usage.Share = float64(usage.Count) / battles
+		summary.Splinters = append(summary.Splinters, *usage)
+	}
+	sort.SliceStable(summary.Splinters, func(i, j int) bool {
+		a, b := summary.Splinters[i], summary.Splinters[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Color < b.Color
+	})
+	return summary
+}
+
+/* Summary of the battles of each mana cap */
+func (p OpponentProfile) SummarizeByManaCap() map[int]ProfileSummary {
+	summaries := make(map[int]ProfileSummary)
+	for _, battle := range p.Battles {
+		manaCap := battle.ManaCap
+		if _, ok := summaries[manaCap]; !ok {
+			summaries[manaCap] = p.Summarize(func(b ProfiledBattle) bool { return b.ManaCap == manaCap })
+		}
+	}
+	return summaries
+}
+
+/* Summary of the battles played with each ruleset */
+func (p OpponentProfile) SummarizeByRuleset() map[Ruleset]ProfileSummary {
+	summaries := make(map[Ruleset]ProfileSummary)
+	for _, battle := range p.Battles {
+		for _, ruleset := range battle.Rulesets {
+			r := ruleset
+			if _, ok := summaries[r]; !ok {
+				summaries[r] = p.Summarize(func(b ProfiledBattle) bool { return RulesetsContains(b.Rulesets, r) })
+			}
+		}
+	}
+	return summaries
+}
+
+/*
+Probability of each team the player played to be played in a match with the rules, most likely first.
+Teams are capped by the level caps of the match and their summoner, then the teams that are illegal under the rules
+are left out. Each battle counts more the closer its mana cap is, the more rulesets it shares with the match
+and the more recent it is.
+*/
+func (p OpponentProfile) PredictTeams(cardDetailMap CardDetailMap, rules TeamRules) []TeamProbability {
+	predictions := make([]TeamProbability, 0)
+	indexes := make(map[string]int)
+	total := 0.0
+	for age := 0; age < len(p.Battles); age++ {
+		battle := p.Battles[len(p.Battles)-1-age]
+		team, _ := ApplyTeamLevelCaps(cardDetailMap, battle.Team, rules.Settings)
+		if len(rules.ValidateBattleTeam(cardDetailMap, team)) > 0 {
+			continue
+		}
+		weight := math.Pow(0.5, float64(age)/PROFILE_RECENCY_HALF_LIFE)
+		if rules.ManaCap > 0 {
+			weight *= math.Exp(-math.Abs(float64(rules.ManaCap-battle.ManaCap)) / PROFILE_MANA_CAP_SCALE)
+		}
+		for _, ruleset := range rules.Rulesets {
+			if RulesetsContains(battle.Rulesets, ruleset) {
+				weight *= 1 + PROFILE_RULESET_BONUS
+			}
+		}
+		total += weight
+
+		key := GetLineupKey(team)
+		if i, ok := indexes[key]; ok {
+			predictions[i].Probability += weight
+			predictions[i].Battles++
+			continue
+		}
+		indexes[key] = len(predictions)
+		predictions = append(predictions, TeamProbability{Team: team, Probability: weight, Battles: 1})
+	}
+
+	for i := range predictions {
+		predictions[i].Probability /= total
+	}
+	sort.SliceStable(predictions, func(i, j int) bool {
+		return predictions[i].Probability > predictions[j].Probability
+	})
+	return predictions
+}
+
+func getSortedCardUsages(usages map[int]*CardUsage, battles float64) []CardUsage {
+	sorted := make([]CardUsage, 0, len(usages))
+	for _, usage := range usages {
+		usage.Share = float64(usage.Count) / battles
+		sorted = append(sorted, *usage)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].CardDetailID < sorted[j].CardDetailID
+	})
+	return sorted
+}
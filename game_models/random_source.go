@@ -0,0 +1,123 @@
+package game_models
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+/* What a random draw of the game decides, used as the label of the draw */
+const (
+	RANDOM_EVENT_DODGE       = "dodge"
+	RANDOM_EVENT_AFFLICTION  = "affliction"
+	RANDOM_EVENT_STUN        = "stun"
+	RANDOM_EVENT_POISON      = "poison"
+	RANDOM_EVENT_RETALIATE   = "retaliate"
+	RANDOM_EVENT_TIE_BREAK   = "tie break"
+	RANDOM_EVENT_SCATTERSHOT = "scattershot target"
+)
+
+/*
+Source of every random draw of a game. The label of a draw (RANDOM_EVENT_*) tells what it decides.
+Draws whose outcome is certain are not made.
+  - Chance: true with the probability p (0 < p < 1)
+  - Pick: one of n equally likely outcomes (n > 1)
+*/
+type RandomSource interface {
+	Chance(label string, p float64) bool
+	Pick(label string, n int) int
+}
+
+/* Sources told which monsters the next draw is about (nil if none), e.g. to record the draws */
+type RandomDrawDescriber interface {
+	DescribeDraw(round int, actor *MonsterCard, target *MonsterCard)
+}
+
+/* Draws from math/rand, the source of games by default */
+type MathRandomSource struct{}
+
+var seedMathRandomOnce sync.Once
+
+func (MathRandomSource) seed() {
+	seedMathRandomOnce.Do(func() {
+		rand.Seed(time.Now().UnixNano())
+	})
+}
+
+func (s MathRandomSource) Chance(label string, p float64) bool {
+	s.seed()
+	return rand.Float64() < p
+}
+
+func (s MathRandomSource) Pick(label string, n int) int {
+	s.seed()
+	return rand.Intn(n)
+}
+
+func (g *Game) SetRandomSource(source RandomSource) {
+	g.randomSource = source
+}
+
+func (g *Game) GetRandomSource() RandomSource {
+	if g.randomSource == nil {
+		return MathRandomSource{}
+	}
+	return g.randomSource
+}
+
+func (g *Game) describeDraw(actor *MonsterCard, target *MonsterCard) {
+	if describer, ok := g.GetRandomSource().(RandomDrawDescriber); ok {
+		describer.DescribeDraw(g.roundNumber, actor, target)
+	}
+}
+
+/* Probability of GetSuccessBelow(chance) to succeed: an integer of 0-100 is drawn and compared to the chance */
+func GetSuccessBelowProbability(chance float64) float64 {
+	return math.Min(math.Max(math.Ceil(chance), 0), 101) / 101
+}
+
+/* Draws a success of the probability from the source, no draw is made when the outcome is certain */
+func rollChance(source RandomSource, label string, p float64) bool {
+	if p <= 0 {
+		return false
+	}
+	if p >= 1 {
+		return true
+	}
+	return source.Chance(label, p)
+}
+
+func rollTieBreaker(source RandomSource) int {
+	if source.Chance(RANDOM_EVENT_TIE_BREAK, 0.5) {
+		return -1
+	}
+	return 1
+}
+
+/* Same as GetSuccessBelow but drawn from the random source of the game, actor and target are what the draw is about */
+func (g *Game) RollSuccessBelow(label string, chance float64, actor *MonsterCard, target *MonsterCard) bool {
+	p := GetSuccessBelowProbability(chance)
+	if p > 0 && p < 1 {
+		g.describeDraw(actor, target)
+	}
+	return rollChance(g.GetRandomSource(), label, p)
+}
+
+/* Same as GetDidDodgeWithConfig but drawn from the random source of the game */
+func (g *Game) RollDodge(attacker *MonsterCard, target *MonsterCard, attackType CardAttackType) bool {
+	dodgeChance := GetDodgeChanceWithConfig(g.GetConfig(), g.rulesets, attacker, target, attackType)
+	if dodgeChance <= 0 {
+		return false
+	}
+	if dodgeChance >= 1 {
+		return true
+	}
+	return g.RollSuccessBelow(RANDOM_EVENT_DODGE, dodgeChance*100, attacker, target)
+}
+
+/* Same as RandomTieBreaker but drawn from the random source of the game */
+func (g *Game) RollTieBreaker(m1 *MonsterCard, m2 *MonsterCard) int {
+	g.describeDraw(m1, m2)
+	return rollTieBreaker(g.GetRandomSource())
+}
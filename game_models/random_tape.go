@@ -0,0 +1,288 @@
+package game_models
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"time"
+)
+
+/* A random draw of a game, recorded by a RandomTape */
+type RandomDraw struct {
+	Round int
+	// RANDOM_EVENT_* of the draw
+	Label string
+	// names of the monsters the draw is about, "" if none
+	Actor  string
+	Target string
+	// probability of success of a Chance draw, 0 for a Pick draw
+	Probability float64
+	// number of outcomes of a Pick draw, 0 for a Chance draw
+	Options int
+	// uniform draw of 0-1 of a Chance draw, it succeeds below the probability
+	Roll    float64
+	Success bool
+	Picked  int
+	// true if the outcome was forced by a RandomOverride
+	Overridden bool
+}
+
+func (d RandomDraw) IsPick() bool {
+	return d.Options > 0
+}
+
+/* Probability of the outcome of the draw */
+func (d RandomDraw) GetOutcomeProbability() float64 {
+	if d.IsPick() {
+		return 1 / float64(d.Options)
+	}
+	if d.Success {
+		return d.Probability
+	}
+	return 1 - d.Probability
+}
+
+/* e.g. "round 2, dodge Chicken vs Sea Monster: 0.37 < 0.25 → false" */
+func (d RandomDraw) String() string {
+	about := ""
+	if d.Actor != "" {
+		about = " " + d.Actor
+	}
+	if d.Target != "" {
+		about += " vs " + d.Target
+	}
+	forced := ""
+	if d.Overridden {
+		forced = " (forced)"
+	}
+	if d.IsPick() {
+		return fmt.Sprintf("round %d, %s%s: %d of %d%s", d.Round, d.Label, about, d.Picked+1, d.Options, forced)
+	}
+	return fmt.Sprintf("round %d, %s%s: %.2f < %.2f → %t%s", d.Round, d.Label, about, d.Roll, d.Probability, d.Success, forced)
+}
+
+/*
+Forces the outcome of the recorded draw at Index when it is replayed.
+Success forces a Chance draw and Picked a Pick draw, nil keeps the outcome.
+*/
+type RandomOverride struct {
+	Index   int
+	Success *bool
+	Picked  *int
+}
+
+func ForceSuccess(index int, success bool) RandomOverride {
+	return RandomOverride{Index: index, Success: &success}
+}
+
+func ForcePick(index int, picked int) RandomOverride {
+	return RandomOverride{Index: index, Picked: &picked}
+}
+
+/* A draw is replayed by the recorded draws of the same round, label and monsters, in the order they were drawn */
+type randomDrawKey struct {
+	round  int
+	label  string
+	actor  string
+	target string
+}
+
+func (d RandomDraw) getKey() randomDrawKey {
+	return randomDrawKey{d.Round, d.Label, d.Actor, d.Target}
+}
+
+/*
+Random source of a game recording every draw. A replay tape replays the rolls of the recorded draws
+of the same round, label and monsters, with the overrides applied: a Chance draw succeeds if the recorded roll
+is below its probability in the replay, so the luck stays the same when an override changes the rest of the game.
+Draws the battle didn't make before are drawn from a generator seeded by the recorded draws,
+so a replay of the same draws and overrides always plays the same game.
+*/
+type RandomTape struct {
+	Draws    []RandomDraw
+	recorded []RandomDraw
+	// indexes of the recorded draws not replayed yet
+	unreplayed map[randomDrawKey][]int
+	overrides  map[int]RandomOverride
+	random     *rand.Rand
+	diverged   int
+	round      int
+	actor      string
+	target     string
+}
+
+func NewRandomTape() *RandomTape {
+	return &RandomTape{
+		Draws:      make([]RandomDraw, 0),
+		unreplayed: make(map[randomDrawKey][]int),
+		overrides:  make(map[int]RandomOverride),
+		random:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func NewReplayTape(recorded []RandomDraw, overrides ...RandomOverride) *RandomTape {
+	t := NewRandomTape()
+	t.recorded = recorded
+	t.random = rand.New(rand.NewSource(getReplaySeed(recorded)))
+	for i, draw := range recorded {
+		t.unreplayed[draw.getKey()] = append(t.unreplayed[draw.getKey()], i)
+	}
+	for _, override := range overrides {
+		t.overrides[override.Index] = override
+	}
+	return t
+}
+
+func getReplaySeed(recorded []RandomDraw) int64 {
+	hash := fnv.New64a()
+	for _, draw := range recorded {
+		fmt.Fprintf(hash, "%d %s %s %s %v %d;", draw.Round, draw.Label, draw.Actor, draw.Target, draw.Roll, draw.Picked)
+	}
+	return int64(hash.Sum64())
+}
+
+/* Number of draws of the replay without a recorded draw to replay */
+func (t *RandomTape) GetDivergedDraws() int {
+	return t.diverged
+}
+
+func (t *RandomTape) DescribeDraw(round int, actor *MonsterCard, target *MonsterCard) {
+	t.round = round
+	t.actor = ""
+	t.target = ""
+	if actor != nil {
+		t.actor = actor.GetName()
+	}
+	if target != nil {
+		t.target = target.GetName()
+	}
+}
+
+func (t *RandomTape) Chance(label string, p float64) bool {
+	draw := t.newDraw(label)
+	draw.Probability = p
+	recordedIndex, isReplayed := t.popRecorded(draw)
+	if isReplayed {
+		draw.Roll = t.recorded[recordedIndex].Roll
+	} else {
+		draw.Roll = t.random.Float64()
+	}
+	draw.Success = draw.Roll < p
+	if override, ok := t.overrides[recordedIndex]; isReplayed && ok && override.Success != nil {
+		draw.Success = *override.Success
+		draw.Overridden = true
+	}
+	t.Draws = append(t.Draws, draw)
+	return draw.Success
+}
+
+func (t *RandomTape) Pick(label string, n int) int {
+	draw := t.newDraw(label)
+	draw.Options = n
+	recordedIndex, isReplayed := t.popRecorded(draw)
+	if isReplayed && t.recorded[recordedIndex].Picked < n {
+		draw.Picked = t.recorded[recordedIndex].Picked
+	} else {
+		draw.Picked = t.random.Intn(n)
+	}
+	if override, ok := t.overrides[recordedIndex]; isReplayed && ok && override.Picked != nil && *override.Picked >= 0 && *override.Picked < n {
+		draw.Picked = *override.Picked
+		draw.Overridden = true
+	}
+	t.Draws = append(t.Draws, draw)
+	return draw.Picked
+}
+
+func (t *RandomTape) newDraw(label string) RandomDraw {
+	draw := RandomDraw{Round: t.round, Label: label, Actor: t.actor, Target: t.target}
+	// the monsters described are only those of the next draw
+	t.actor = ""
+	t.target = ""
+	return draw
+}
+
+/* Index of the next recorded draw of the same round, label and monsters, false if there is none */
+func (t *RandomTape) popRecorded(draw RandomDraw) (int, bool) {
+	if t.recorded == nil {
+		return -1, false
+	}
+	key := draw.getKey()
+	indexes := t.unreplayed[key]
+	if len(indexes) == 0 {
+		t.diverged++
+		return -1, false
+	}
+	t.unreplayed[key] = indexes[1:]
+	return indexes[0], true
+}
+
+/* Plays the game and returns the tape of its draws */
+func RecordGame(game *Game) *RandomTape {
+	tape := NewRandomTape()
+	game.SetRandomSource(tape)
+	game.PlayGame()
+	return tape
+}
+
+/* Plays a new game with the recorded draws and the overrides */
+func ReplayGame(createGame func() *Game, recorded []RandomDraw, overrides ...RandomOverride) (*Game, *RandomTape) {
+	game := createGame()
+	tape := NewReplayTape(recorded, overrides...)
+	game.SetRandomSource(tape)
+	game.PlayGame()
+	return game, tape
+}
+
+/* A recorded draw whose other outcome changes the winner of the game */
+type PivotalDraw struct {
+	Index int
+	Draw  RandomDraw
+	// override of the other outcome and the winner of the game with it
+	Override RandomOverride
+	Winner   TeamNumber
+}
+
+/* Probability of the outcome of the override */
+func (p PivotalDraw) GetOverrideProbability() float64 {
+	if p.Draw.IsPick() {
+		return 1 / float64(p.Draw.Options)
+	}
+	if p.Override.Success != nil && *p.Override.Success {
+		return p.Draw.Probability
+	}
+	return 1 - p.Draw.Probability
+}
+
+/* The draw went against the team: the team wins with the other outcome, which was more likely than the recorded one */
+func (p PivotalDraw) IsBadLuckFor(team TeamNumber) bool {
+	return p.Winner == team && p.GetOverrideProbability() > p.Draw.GetOutcomeProbability()
+}
+
+/*
+Replays the recorded game with each draw given its other outcomes (one by one) and returns the draws changing the winner.
+createGame must return a new game of the recorded battle each time.
+*/
+func FindPivotalDraws(createGame func() *Game, recorded []RandomDraw) []PivotalDraw {
+	replayed, _ := ReplayGame(createGame, recorded)
+	winner := replayed.GetWinner()
+
+	pivotalDraws := make([]PivotalDraw, 0)
+	for i, draw := range recorded {
+		overrides := []RandomOverride{ForceSuccess(i, !draw.Success)}
+		if draw.IsPick() {
+			overrides = make([]RandomOverride, 0, draw.Options-1)
+			for picked := 0; picked < draw.Options; picked++ {
+				if picked != draw.Picked {
+					overrides = append(overrides, ForcePick(i, picked))
+				}
+			}
+		}
+		for _, override := range overrides {
+			game, _ := ReplayGame(createGame, recorded, override)
+			if game.GetWinner() != winner {
+				pivotalDraws = append(pivotalDraws, PivotalDraw{Index: i, Draw: draw, Override: override, Winner: game.GetWinner()})
+			}
+		}
+	}
+	return pivotalDraws
+}
@@ -0,0 +1,158 @@
+package game_models
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const RULES_VERSION_CURRENT = "current"
+
+/*
+How abilities behaved in a rules version where the engine can't tell it from a handler. The zero value is the current rules.
+  - TauntIgnoredByTargetAbilities: Sneak, Snipe and Opportunity pick their own targets instead of the taunt monster
+  - DivineShieldStopsBlast: an attack absorbed by Divine Shield doesn't blast the neighbors of the target
+  - PiercingMeleeOnly: only melee attacks pierce armor
+*/
+type RulesBehavior struct {
+	TauntIgnoredByTargetAbilities bool
+	DivineShieldStopsBlast        bool
+	PiercingMeleeOnly             bool
+}
+
+/*
+Rules of the game until a date: the balance parameters and how abilities behaved.
+A version is live from the end of the previous version until EffectiveUntil.
+AbilityHandlers replace the registered handlers of their ability (hooks and stat modifiers) in games of this version,
+e.g. a RetaliateHandler with another trigger. Balance parameters like the retaliate chance are in Config.
+*/
+type RulesVersion struct {
+	Name string
+	// zero time: still live
+	EffectiveUntil  time.Time
+	Config          EngineConfig
+	Behavior        RulesBehavior
+	AbilityHandlers []AbilityHandler
+}
+
+func (v RulesVersion) IsLive() bool {
+	return v.EffectiveUntil.IsZero()
+}
+
+/* Rules live today */
+func NewCurrentRulesVersion() RulesVersion {
+	return RulesVersion{Name: RULES_VERSION_CURRENT, Config: DefaultEngineConfig()}
+}
+
+/* Only the current rules are built in, historical versions are registered with the sources of their rules */
+func getBuiltInRulesVersions() []RulesVersion {
+	return []RulesVersion{NewCurrentRulesVersion()}
+}
+
+/* Registered versions, oldest first. Other versions are added with RegisterRulesVersion. Guarded by rulesVersionsMutex */
+var rulesVersions = getBuiltInRulesVersions()
+var rulesVersionsMutex sync.RWMutex
+
+/* Registers a rules version. Replaces the version of the same name. */
+func RegisterRulesVersion(version RulesVersion) {
+	rulesVersionsMutex.Lock()
+	defer rulesVersionsMutex.Unlock()
+	replaced := false
+	for i, v := range rulesVersions {
+		if v.Name == version.Name {
+			rulesVersions[i] = version
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		rulesVersions = append(rulesVersions, version)
+	}
+	sort.SliceStable(rulesVersions, func(i, j int) bool {
+		a, b := rulesVersions[i], rulesVersions[j]
+		if a.IsLive() || b.IsLive() {
+			return !a.IsLive() && b.IsLive()
+		}
+		return a.EffectiveUntil.Before(b.EffectiveUntil)
+	})
+}
+
+/* Removes a registered version. The current version can't be removed. */
+func UnregisterRulesVersion(name string) error {
+	if name == RULES_VERSION_CURRENT {
+		return fmt.Errorf("the %q rules version can't be removed", name)
+	}
+	rulesVersionsMutex.Lock()
+	defer rulesVersionsMutex.Unlock()
+	for i, v := range rulesVersions {
+		if v.Name == name {
+			rulesVersions = append(rulesVersions[:i:i], rulesVersions[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown rules version %q", name)
+}
+
+/* Removes the registered versions and restores the built in ones */
+func ResetRulesVersions() {
+	rulesVersionsMutex.Lock()
+	defer rulesVersionsMutex.Unlock()
+	rulesVersions = getBuiltInRulesVersions()
+}
+
+func GetRulesVersion(name string) (RulesVersion, error) {
+	rulesVersionsMutex.RLock()
+	defer rulesVersionsMutex.RUnlock()
+	for _, v := range rulesVersions {
+		if v.Name == name {
+			return v, nil
+		}
+	}
+	return RulesVersion{}, fmt.Errorf("unknown rules version %q", name)
+}
+
+/* Versions, oldest first */
+func GetRulesVersions() []RulesVersion {
+	rulesVersionsMutex.RLock()
+	defer rulesVersionsMutex.RUnlock()
+	versions := make([]RulesVersion, len(rulesVersions))
+	copy(versions, rulesVersions)
+	return versions
+}
+
+/* Version that was live at the time: the oldest one that was still effective */
+func GetRulesVersionAt(at time.Time) RulesVersion {
+	rulesVersionsMutex.RLock()
+	defer rulesVersionsMutex.RUnlock()
+	for _, v := range rulesVersions {
+		if v.IsLive() || at.Before(v.EffectiveUntil) {
+			return v
+		}
+	}
+	return rulesVersions[len(rulesVersions)-1]
+}
+
+/* Registered version that was live when the battle was played (BattleHistory.CreatedDate, e.g. "2022-06-22T10:09:40.213Z") */
+func GetRulesVersionOfBattle(historicBattle BattleHistory) (RulesVersion, error) {
+	createdAt, err := time.Parse(time.RFC3339, historicBattle.CreatedDate)
+	if err != nil {
+		return RulesVersion{}, err
+	}
+	return GetRulesVersionAt(createdAt), nil
+}
+
+func (g *Game) GetRulesBehavior() RulesBehavior {
+	return g.rulesBehavior
+}
+
+/* Same as Create but the game is played with the rules of the version */
+func (g *Game) CreateWithRulesVersion(team1, team2 *GameTeam, rulesets []Ruleset, shouldLog bool, version RulesVersion) {
+	g.CreateWithConfig(team1, team2, rulesets, shouldLog, version.Config)
+	g.rulesBehavior = version.Behavior
+	g.abilityHandlerOverrides = nil
+	g.shareAbilityHandlerOverrides()
+	for _, h := range version.AbilityHandlers {
+		g.SetAbilityHandler(h)
+	}
+}
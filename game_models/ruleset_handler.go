@@ -0,0 +1,261 @@
+package game_models
+
+import "fmt"
+
+/* A card a team can't play under a ruleset (e.g. a legendary in Lost Legendaries) */
+type TeamRestrictionError struct {
+	Ruleset      Ruleset
+	CardDetailID int
+	CardName     string
+	Reason       string
+}
+
+func (e *TeamRestrictionError) Error() string {
+	return fmt.Sprintf("%s: %s (%d) %s", e.Ruleset, e.CardName, e.CardDetailID, e.Reason)
+}
+
+/*
+Behavior of a ruleset. The game calls the hooks of the handlers of its rulesets in registration order.
+Embed BaseRulesetHandler to only implement the hooks the ruleset needs.
+  - ValidateTeam: cards the team can't play under the ruleset
+  - OnPreGame: before the summoner and monster pre game buffs
+  - OnPostBuff: after the pre game buffs / debuffs
+  - OnPreRound: at the start of every round
+  - ModifyTurnOrder: changes the order of the monsters that haven't moved (the first one moves next)
+  - ModifyDodgeChance: changes the chance of the target to dodge the attack
+  - OnPostRound: at the end of every round, before poison
+*/
+type RulesetHandler interface {
+	GetRuleset() Ruleset
+	ValidateTeam(team *GameTeam) []error
+	OnPreGame(team1, team2 *GameTeam)
+	OnPostBuff(team1, team2 *GameTeam)
+	OnPreRound(g *Game)
+	ModifyTurnOrder(g *Game, turnOrder []*MonsterCard) []*MonsterCard
+	ModifyDodgeChance(attacker, target *MonsterCard, attackType CardAttackType, dodgeChance float64) float64
+	OnPostRound(g *Game)
+}
+
+/* No-op implementation of every hook of RulesetHandler */
+type BaseRulesetHandler struct {
+	Ruleset Ruleset
+}
+
+func (h BaseRulesetHandler) GetRuleset() Ruleset               { return h.Ruleset }
+func (BaseRulesetHandler) ValidateTeam(team *GameTeam) []error { return nil }
+func (BaseRulesetHandler) OnPreGame(team1, team2 *GameTeam)    {}
+func (BaseRulesetHandler) OnPostBuff(team1, team2 *GameTeam)   {}
+func (BaseRulesetHandler) OnPreRound(g *Game)                  {}
+func (BaseRulesetHandler) OnPostRound(g *Game)                 {}
+func (BaseRulesetHandler) ModifyTurnOrder(g *Game, turnOrder []*MonsterCard) []*MonsterCard {
+	return turnOrder
+}
+func (BaseRulesetHandler) ModifyDodgeChance(attacker, target *MonsterCard, attackType CardAttackType, dodgeChance float64) float64 {
+	return dodgeChance
+}
+
+/* Ruleset that changes every monster of both teams before (or after) the pre game buffs */
+type MonsterRulesetHandler struct {
+	BaseRulesetHandler
+	PreGame  func(m *MonsterCard)
+	PostBuff func(m *MonsterCard)
+}
+
+func (h MonsterRulesetHandler) OnPreGame(team1, team2 *GameTeam) {
+	if h.PreGame != nil {
+		ApplyToBothTeamMonsters(team1, team2, h.PreGame)
+	}
+}
+
+func (h MonsterRulesetHandler) OnPostBuff(team1, team2 *GameTeam) {
+	if h.PostBuff != nil {
+		ApplyToBothTeamMonsters(team1, team2, h.PostBuff)
+	}
+}
+
+/* Ruleset that only allows some monsters (and summoners) in a team */
+type TeamRestrictionRulesetHandler struct {
+	BaseRulesetHandler
+	Reason          string
+	IsMonsterValid  func(m *MonsterCard) bool
+	IsSummonerValid func(s *SummonerCard) bool
+}
+
+func (h TeamRestrictionRulesetHandler) ValidateTeam(team *GameTeam) []error {
+	errs := make([]error, 0)
+	summoner := team.GetSummoner()
+	if h.IsSummonerValid != nil && summoner != nil && !h.IsSummonerValid(summoner) {
+		errs = append(errs, &TeamRestrictionError{Ruleset: h.Ruleset, CardDetailID: summoner.GetCardDetail().ID, CardName: summoner.GetName(), Reason: h.Reason})
+	}
+	if h.IsMonsterValid != nil {
+		for _, m := range team.GetMonstersList() {
+			if !h.IsMonsterValid(m) {
+				errs = append(errs, &TeamRestrictionError{Ruleset: h.Ruleset, CardDetailID: m.GetCardDetail().ID, CardName: m.GetName(), Reason: h.Reason})
+			}
+		}
+	}
+	return errs
+}
+
+/* Registered handlers in registration order. Built in rulesets come first, pre game ones in the order the engine used to apply them */
+var rulesetHandlers = getBuiltInRulesetHandlers()
+
+/* Registers the handler of a ruleset (e.g. a house rule of a private tournament). Replaces the handler already registered for the ruleset, keeping its order. */
+func RegisterRulesetHandler(handler RulesetHandler) {
+	for i, h := range rulesetHandlers {
+		if h.GetRuleset() == handler.GetRuleset() {
+			rulesetHandlers[i] = handler
+			return
+		}
+	}
+	rulesetHandlers = append(rulesetHandlers, handler)
+}
+
+func GetRulesetHandler(ruleset Ruleset) RulesetHandler {
+	for _, h := range rulesetHandlers {
+		if h.GetRuleset() == ruleset {
+			return h
+		}
+	}
+	return nil
+}
+
+/* Tells if the ruleset has a registered handler */
+func HasRulesetHandler(ruleset Ruleset) bool {
+	return GetRulesetHandler(ruleset) != nil
+}
+
+/* Handlers of the rulesets, in registration order. Rulesets without a handler are ignored */
+func GetRulesetHandlers(rulesets []Ruleset) []RulesetHandler {
+	handlers := make([]RulesetHandler, 0)
+	if len(rulesets) == 0 {
+		return handlers
+	}
+	for _, h := range rulesetHandlers {
+		if RulesetsContains(rulesets, h.GetRuleset()) {
+			handlers = append(handlers, h)
+		}
+	}
+	return handlers
+}
+
+/* Cards of the team that can't be played under the rulesets */
+func ValidateTeamForRulesets(rulesets []Ruleset, team *GameTeam) []error {
+	errs := make([]error, 0)
+	for _, h := range GetRulesetHandlers(rulesets) {
+		errs = append(errs, h.ValidateTeam(team)...)
+	}
+	return errs
+}
+
+func (g *Game) HasRuleset(ruleset Ruleset) bool {
+	return RulesetsContains(g.rulesets, ruleset)
+}
+
+func getBuiltInRulesetHandlers() []RulesetHandler {
+	monsterRuleset := func(ruleset Ruleset, preGame func(m *MonsterCard)) RulesetHandler {
+		return MonsterRulesetHandler{BaseRulesetHandler: BaseRulesetHandler{Ruleset: ruleset}, PreGame: preGame}
+	}
+	restriction := func(ruleset Ruleset, reason string, isMonsterValid func(m *MonsterCard) bool) TeamRestrictionRulesetHandler {
+		return TeamRestrictionRulesetHandler{BaseRulesetHandler: BaseRulesetHandler{Ruleset: ruleset}, Reason: reason, IsMonsterValid: isMonsterValid}
+	}
+	littleLeague := restriction(RULESET_LITTLE_LEAGUE, "costs more than 4 mana", func(m *MonsterCard) bool { return m.Mana <= 4 })
+	littleLeague.IsSummonerValid = func(s *SummonerCard) bool { return s.Mana <= 4 }
+
+	return []RulesetHandler{
+		// pre game
+		monsterRuleset(RULESET_ARMORED_UP, ApplyArmorUpRuleset),
+		monsterRuleset(RULESET_BACK_TO_BASICS, ApplyBackToBasicsRuleset),
+		monsterRuleset(RULESET_CLOSE_RANGE, ApplyCloseRangeRuleset),
+		monsterRuleset(RULESET_EQUAL_OPPORTUNITY, ApplyEqualOpportunityRuleset),
+		EqualizerRulesetHandler{BaseRulesetHandler{Ruleset: RULESET_EQUALIZER}},
+		monsterRuleset(RULESET_EXPLOSIVE_WEAPONRY, ApplyExplosiveWeaponRuleset),
+		monsterRuleset(RULESET_FOG_OF_WAR, ApplyFogOfWarRuleset),
+		monsterRuleset(RULESET_HEALED_OUT, ApplyHealedOutRuleset),
+		monsterRuleset(RULESET_HEAVY_HITTERS, ApplyHeavyHittersRuleset),
+		monsterRuleset(RULESET_HOLY_PROTECTION, ApplyHolyProtectionRuleset),
+		monsterRuleset(RULESET_MELEE_MAYHEM, ApplyMeleeMayhemRuleset),
+		monsterRuleset(RULESET_NOXIOUS_FUMES, ApplyNoxiousFumesRuleset),
+		SilencedSummonersRulesetHandler{BaseRulesetHandler{Ruleset: RULESET_SILENCED_SUMMONERS}},
+		monsterRuleset(RULESET_SPREADING_FURY, ApplySpreadingFuryRuleset),
+		monsterRuleset(RULESET_SUPER_SNEAK, ApplySuperSneakRuleset),
+		monsterRuleset(RULESET_WEAK_MAGIC, ApplyWeakMagicRuleset),
+		monsterRuleset(RULESET_TARGET_PRACTICE, ApplyTargetPracticeRuleset),
+		monsterRuleset(RULESET_FIRE_AND_REGRET, ApplyFireAndRegretRuleset),
+		monsterRuleset(RULESET_COUNTERSPELL, ApplyCounterspellRuleset),
+		monsterRuleset(RULESET_WHAT_DOESNT_KILL_YOU, ApplyWhatDoesntKillYouRuleset),
+		monsterRuleset(RULESET_ARE_YOU_NOT_ENTERTAINED, ApplyAreYouNotEntertainedRuleset),
+
+		// post buff
+		MonsterRulesetHandler{BaseRulesetHandler: BaseRulesetHandler{Ruleset: RULESET_UNPROTECTED}, PostBuff: ApplyUnprotectedRuleset},
+
+		// in battle
+		AimTrueRulesetHandler{BaseRulesetHandler{Ruleset: RULESET_AIM_TRUE}},
+		EarthquakeRulesetHandler{BaseRulesetHandler{Ruleset: RULESET_EARTHQUAKE}},
+		ReverseSpeedRulesetHandler{BaseRulesetHandler{Ruleset: RULESET_REVERSE_SPEED}},
+		// Trample keeps going after a kill (checked by the trample)
+		BaseRulesetHandler{Ruleset: RULESET_STAMPEDE},
+		BaseRulesetHandler{Ruleset: RULESET_STANDARD},
+
+		// team restrictions
+		restriction(RULESET_BROKEN_ARROWS, "has a ranged attack", func(m *MonsterCard) bool { return m.Ranged == 0 }),
+		restriction(RULESET_EVEN_STEVENS, "costs odd mana", func(m *MonsterCard) bool { return m.Mana%2 == 0 }),
+		restriction(RULESET_KEEP_YOUR_DISTANCE, "has a melee attack", func(m *MonsterCard) bool { return m.Melee == 0 }),
+		littleLeague,
+		restriction(RULESET_LOST_LEGENDARIES, "is legendary", func(m *MonsterCard) bool { return m.GetRarity() != 4 }),
+		restriction(RULESET_LOST_MAGIC, "has a magic attack", func(m *MonsterCard) bool { return m.Magic == 0 }),
+		restriction(RULESET_ODD_ONES_OUT, "costs even mana", func(m *MonsterCard) bool { return m.Mana%2 == 1 }),
+		restriction(RULESET_RISE_OF_THE_COMMONS, "is not common or rare", func(m *MonsterCard) bool { return m.GetRarity() <= 2 }),
+		restriction(RULESET_TAKING_SIDES, "is neutral", func(m *MonsterCard) bool { return m.GetCardDetail().Color != COLOR_GRAY }),
+		restriction(RULESET_UP_CLOSE_AND_PERSONAL, "has no melee attack", func(m *MonsterCard) bool { return m.Melee > 0 }),
+		restriction(RULESET_GOING_THE_DISTANCE, "has no ranged attack", func(m *MonsterCard) bool { return m.Ranged > 0 }),
+		restriction(RULESET_WANDS_OUT, "has no magic attack", func(m *MonsterCard) bool { return m.Magic > 0 }),
+	}
+}
+
+type EqualizerRulesetHandler struct {
+	BaseRulesetHandler
+}
+
+func (EqualizerRulesetHandler) OnPreGame(team1, team2 *GameTeam) {
+	ApplyEqualizer(team1, team2)
+}
+
+type SilencedSummonersRulesetHandler struct {
+	BaseRulesetHandler
+}
+
+func (SilencedSummonersRulesetHandler) OnPreGame(team1, team2 *GameTeam) {
+	ApplySilencedSummonersRuleset(team1, team2)
+}
+
+/* Attacks can't be dodged */
+type AimTrueRulesetHandler struct {
+	BaseRulesetHandler
+}
+
+func (AimTrueRulesetHandler) ModifyDodgeChance(attacker, target *MonsterCard, attackType CardAttackType, dodgeChance float64) float64 {
+	return 0
+}
+
+type EarthquakeRulesetHandler struct {
+	BaseRulesetHandler
+}
+
+func (EarthquakeRulesetHandler) OnPostRound(g *Game) {
+	g.DoPostRoundEarthquake(g.team1.GetAliveMonsters())
+	g.DoPostRoundEarthquake(g.team2.GetAliveMonsters())
+}
+
+/* Slowest monster moves first (the speed difference of dodge and Bloodlust are reversed too) */
+type ReverseSpeedRulesetHandler struct {
+	BaseRulesetHandler
+}
+
+func (ReverseSpeedRulesetHandler) ModifyTurnOrder(g *Game, turnOrder []*MonsterCard) []*MonsterCard {
+	reversed := make([]*MonsterCard, 0, len(turnOrder))
+	for i := len(turnOrder) - 1; i >= 0; i-- {
+		reversed = append(reversed, turnOrder[i])
+	}
+	return reversed
+}
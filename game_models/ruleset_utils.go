@@ -3,62 +3,14 @@ package game_models
 import utils "github.com/YukiUmetsu/go-spl-simulator/game_utils"
 
 func DoRulesetPreGameBuff(rulesets []Ruleset, team1, team2 *GameTeam) {
-	if utils.Contains(rulesets, RULESET_ARMORED_UP) {
-		ApplyToBothTeamMonsters(team1, team2, ApplyArmorUpRuleset)
-	}
-	if utils.Contains(rulesets, RULESET_BACK_TO_BASICS) {
-		ApplyToBothTeamMonsters(team1, team2, ApplyBackToBasicsRuleset)
-	}
-	if utils.Contains(rulesets, RULESET_CLOSE_RANGE) {
-		ApplyToBothTeamMonsters(team1, team2, ApplyCloseRangeRuleset)
-	}
-	if utils.Contains(rulesets, RULESET_EQUAL_OPPORTUNITY) {
-		ApplyToBothTeamMonsters(team1, team2, ApplyEqualOpportunityRuleset)
-	}
-	if utils.Contains(rulesets, RULESET_EQUALIZER) {
-		ApplyEqualizer(team1, team2)
-	}
-	if utils.Contains(rulesets, RULESET_EXPLOSIVE_WEAPONRY) {
-		ApplyToBothTeamMonsters(team1, team2, ApplyExplosiveWeaponRuleset)
-	}
-	if utils.Contains(rulesets, RULESET_FOG_OF_WAR) {
-		ApplyToBothTeamMonsters(team1, team2, ApplyFogOfWarRuleset)
-	}
-	if utils.Contains(rulesets, RULESET_HEALED_OUT) {
-		ApplyToBothTeamMonsters(team1, team2, ApplyHealedOutRuleset)
-	}
-	if utils.Contains(rulesets, RULESET_HEAVY_HITTERS) {
-		ApplyToBothTeamMonsters(team1, team2, ApplyHeavyHittersRuleset)
-	}
-	if utils.Contains(rulesets, RULESET_HOLY_PROTECTION) {
-		ApplyToBothTeamMonsters(team1, team2, ApplyHolyProtectionRuleset)
-	}
-	if utils.Contains(rulesets, RULESET_MELEE_MAYHEM) {
-		ApplyToBothTeamMonsters(team1, team2, ApplyMeleeMayhemRuleset)
-	}
-	if utils.Contains(rulesets, RULESET_NOXIOUS_FUMES) {
-		ApplyToBothTeamMonsters(team1, team2, ApplyNoxiousFumesRuleset)
-	}
-	if utils.Contains(rulesets, RULESET_SILENCED_SUMMONERS) {
-		ApplySilencedSummonersRuleset(team1, team2)
-	}
-	if utils.Contains(rulesets, RULESET_SPREADING_FURY) {
-		ApplyToBothTeamMonsters(team1, team2, ApplySpreadingFuryRuleset)
-	}
-	if utils.Contains(rulesets, RULESET_SUPER_SNEAK) {
-		ApplyToBothTeamMonsters(team1, team2, ApplySuperSneakRuleset)
-	}
-	if utils.Contains(rulesets, RULESET_WEAK_MAGIC) {
-		ApplyToBothTeamMonsters(team1, team2, ApplyWeakMagicRuleset)
-	}
-	if utils.Contains(rulesets, RULESET_TARGET_PRACTICE) {
-		ApplyToBothTeamMonsters(team1, team2, ApplyTargetPracticeRuleset)
+	for _, h := range GetRulesetHandlers(rulesets) {
+		h.OnPreGame(team1, team2)
 	}
 }
 
 func DoRulesetPreGamePostBuff(rulesets []Ruleset, team1, team2 *GameTeam) {
-	if utils.Contains(rulesets, RULESET_UNPROTECTED) {
-		ApplyToBothTeamMonsters(team1, team2, ApplyUnprotectedRuleset)
+	for _, h := range GetRulesetHandlers(rulesets) {
+		h.OnPostBuff(team1, team2)
 	}
 }
 
@@ -189,6 +141,34 @@ func ApplyHeavyHittersRuleset(m *MonsterCard) {
 	m.AddAbilitiesWithArray([]Ability{ABILITY_KNOCK_OUT})
 }
 
+/* All monsters have Return Fire */
+func ApplyFireAndRegretRuleset(m *MonsterCard) {
+	if !m.HasAbility(ABILITY_RETURN_FIRE) {
+		m.AddAbilitiesWithArray([]Ability{ABILITY_RETURN_FIRE})
+	}
+}
+
+/* All monsters have Magic Reflect */
+func ApplyCounterspellRuleset(m *MonsterCard) {
+	if !m.HasAbility(ABILITY_MAGIC_REFLECT) {
+		m.AddAbilitiesWithArray([]Ability{ABILITY_MAGIC_REFLECT})
+	}
+}
+
+/* All monsters have enrage */
+func ApplyWhatDoesntKillYouRuleset(m *MonsterCard) {
+	if !m.HasAbility(ABILITY_ENRAGE) {
+		m.AddAbilitiesWithArray([]Ability{ABILITY_ENRAGE})
+	}
+}
+
+/* All monsters have bloodlust */
+func ApplyAreYouNotEntertainedRuleset(m *MonsterCard) {
+	if !m.HasAbility(ABILITY_BLOODLUST) {
+		m.AddAbilitiesWithArray([]Ability{ABILITY_BLOODLUST})
+	}
+}
+
 func RulesetsContains(rulesets []Ruleset, ruleset Ruleset) bool {
 	for _, r := range rulesets {
 		if r == ruleset {
@@ -204,7 +184,7 @@ func ApplyEarthquake(g *Game, m *MonsterCard) BattleDamage {
 	}
 
 	if !m.HasAbility(ABILITY_FLYING) || m.HasDebuff(ABILITY_SNARE) {
-		return HitMonsterWithPhysical(g, m, EARTHQUAKE_DAMAGE)
+		return HitMonsterWithPhysical(g, m, g.GetConfig().EarthquakeDamage)
 	}
 
 	return BattleDamage{}
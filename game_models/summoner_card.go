@@ -11,27 +11,22 @@ type SummonerCard struct {
 	cardDetail CardDetail
 }
 
-func (c *SummonerCard) Setup(cardDetail CardDetail, cardLevel int) {
+/* Sets the card up from its detail. Returns a *CardDecodeError (with the card id) if the card is malformed, the card then has no stats */
+func (c *SummonerCard) Setup(cardDetail CardDetail, cardLevel int) error {
 	c.cardDetail = cardDetail
 	c.CardLevel = cardLevel - 1
-	var summonerStats FlatCardStats
-
-	var abilities []Ability = make([]Ability, 0)
-	for _, a := range cardDetail.Stats.Abilities {
-		if len(a.(string)) < 1 {
-			continue
-		}
-		abilities = append(abilities, Ability(a.(string)))
+
+	decoded, err := DecodeCardStats(cardDetail)
+	if err == nil && !decoded.IsSummoner {
+		err = &CardDecodeError{CardDetailID: cardDetail.ID, Name: cardDetail.Name, Field: "health", Reason: "expected a summoner with flat stats"}
+	}
+	if err != nil {
+		return err
 	}
-	summonerStats.Abilities = abilities
-	summonerStats.Mana = int(cardDetail.Stats.Mana.(float64))
-	summonerStats.Attack = int(cardDetail.Stats.Attack.(float64))
-	summonerStats.Ranged = int(cardDetail.Stats.Ranged.(float64))
-	summonerStats.Magic = int(cardDetail.Stats.Magic.(float64))
-	summonerStats.Armor = int(cardDetail.Stats.Armor.(float64))
-	summonerStats.Speed = int(cardDetail.Stats.Speed.(float64))
-	summonerStats.Health = int(cardDetail.Stats.Health.(float64))
+	summonerStats := decoded.SummonerStats
+	summonerStats.Abilities = decoded.GetAbilitiesOfLevel(utils.GetBigger(cardLevel, 1))
 	c.SetStats(summonerStats)
+	return nil
 }
 
 func (c *SummonerCard) SetTeam(teamNumber TeamNumber) {
@@ -146,6 +141,7 @@ func (c *SummonerCard) GetCardLevel() int {
 
 func (c *SummonerCard) GetCleanCard() *SummonerCard {
 	var summoner *SummonerCard = &SummonerCard{}
-	summoner.Setup(c.cardDetail, c.GetCardLevel()+1)
+	// the card was set up from the same detail and level before
+	_ = summoner.Setup(c.cardDetail, c.GetCardLevel()+1)
 	return summoner
 }
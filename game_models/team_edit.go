@@ -0,0 +1,138 @@
+package game_models
+
+import "fmt"
+
+type TeamEditKind string
+
+const (
+	// replaces the monster at Position with Card
+	TEAM_EDIT_SWAP TeamEditKind = "swap"
+	// inserts Card at Position, the monsters behind move back
+	TEAM_EDIT_ADD TeamEditKind = "add"
+	// removes the monster at Position
+	TEAM_EDIT_REMOVE TeamEditKind = "remove"
+	// moves the monster at Position to NewPosition
+	TEAM_EDIT_MOVE TeamEditKind = "move"
+	// replaces the summoner with Card
+	TEAM_EDIT_SUMMONER TeamEditKind = "summoner"
+	// sets the level of the card at Position (SUMMONER_POSITION for the summoner) to Level
+	TEAM_EDIT_LEVEL TeamEditKind = "level"
+)
+
+/* A change to a team of a battle. Positions start at 0. */
+type TeamEdit struct {
+	Team        TeamNumber
+	Kind        TeamEditKind
+	Position    int
+	NewPosition int
+	Card        CollectionCard
+	Level       int
+}
+
+type TeamEditError struct {
+	Edit   TeamEdit
+	Reason string
+}
+
+func (e *TeamEditError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Edit, e.Reason)
+}
+
+func (e TeamEdit) String() string {
+	prefix := fmt.Sprintf("team %d", e.Team)
+	switch e.Kind {
+	case TEAM_EDIT_SWAP:
+		return fmt.Sprintf("%s: swap position %d with card %d", prefix, e.Position, e.Card.CardDetailID)
+	case TEAM_EDIT_ADD:
+		return fmt.Sprintf("%s: add card %d at position %d", prefix, e.Card.CardDetailID, e.Position)
+	case TEAM_EDIT_REMOVE:
+		return fmt.Sprintf("%s: remove position %d", prefix, e.Position)
+	case TEAM_EDIT_MOVE:
+		return fmt.Sprintf("%s: move position %d to %d", prefix, e.Position, e.NewPosition)
+	case TEAM_EDIT_SUMMONER:
+		return fmt.Sprintf("%s: change the summoner to card %d", prefix, e.Card.CardDetailID)
+	case TEAM_EDIT_LEVEL:
+		return fmt.Sprintf("%s: set the level of position %d to %d", prefix, e.Position, e.Level)
+	}
+	return fmt.Sprintf("%s: %s", prefix, e.Kind)
+}
+
+/* Returns a copy of the team with the edit applied. The team is not modified. */
+func (e TeamEdit) Apply(team BattleTeam) (BattleTeam, error) {
+	edited := team
+	edited.Monsters = make([]CollectionCard, len(team.Monsters))
+	copy(edited.Monsters, team.Monsters)
+	editErr := func(reason string, args ...any) (BattleTeam, error) {
+		return team, &TeamEditError{Edit: e, Reason: fmt.Sprintf(reason, args...)}
+	}
+	isMonsterPosition := func(position int) bool {
+		return position >= 0 && position < len(team.Monsters)
+	}
+
+	switch e.Kind {
+	case TEAM_EDIT_SWAP:
+		if !isMonsterPosition(e.Position) {
+			return editErr("no monster at position %d", e.Position)
+		}
+		edited.Monsters[e.Position] = e.Card
+	case TEAM_EDIT_ADD:
+		if e.Position < 0 || e.Position > len(team.Monsters) {
+			return editErr("position %d out of 0-%d", e.Position, len(team.Monsters))
+		}
+		if len(team.Monsters) >= MAX_TEAM_MONSTERS {
+			return editErr("the team already has %d monsters", MAX_TEAM_MONSTERS)
+		}
+		edited.Monsters = append(edited.Monsters[:e.Position], append([]CollectionCard{e.Card}, edited.Monsters[e.Position:]...)...)
+	case TEAM_EDIT_REMOVE:
+		if !isMonsterPosition(e.Position) {
+			return editErr("no monster at position %d", e.Position)
+		}
+		if len(team.Monsters) == 1 {
+			return editErr("can't remove the last monster")
+		}
+		edited.Monsters = append(edited.Monsters[:e.Position], edited.Monsters[e.Position+1:]...)
+	case TEAM_EDIT_MOVE:
+		if !isMonsterPosition(e.Position) || !isMonsterPosition(e.NewPosition) {
+			return editErr("positions %d and %d must be in 0-%d", e.Position, e.NewPosition, len(team.Monsters)-1)
+		}
+		moved := edited.Monsters[e.Position]
+		edited.Monsters = append(edited.Monsters[:e.Position], edited.Monsters[e.Position+1:]...)
+		edited.Monsters = append(edited.Monsters[:e.NewPosition], append([]CollectionCard{moved}, edited.Monsters[e.NewPosition:]...)...)
+	case TEAM_EDIT_SUMMONER:
+		edited.Summoner = e.Card
+	case TEAM_EDIT_LEVEL:
+		if e.Level < 1 {
+			return editErr("level %d is below 1", e.Level)
+		}
+		if e.Position == SUMMONER_POSITION {
+			edited.Summoner.Level = e.Level
+		} else if isMonsterPosition(e.Position) {
+			edited.Monsters[e.Position].Level = e.Level
+		} else {
+			return editErr("no monster at position %d", e.Position)
+		}
+	default:
+		return editErr("unknown edit")
+	}
+	return edited, nil
+}
+
+/* Returns a copy of the battle with the edits applied in order */
+func ApplyTeamEdits(battleDetails BattleDetails, edits []TeamEdit) (BattleDetails, error) {
+	edited := battleDetails
+	for _, e := range edits {
+		var err error
+		switch e.Team {
+		case TEAM_NUM_ONE:
+			edited.Team1, err = e.Apply(edited.Team1)
+		case TEAM_NUM_TWO:
+			edited.Team2, err = e.Apply(edited.Team2)
+		default:
+			err = &TeamEditError{Edit: e, Reason: "unknown team"}
+		}
+		if err != nil {
+			return battleDetails, err
+		}
+	}
+	return edited, nil
+}
@@ -0,0 +1,136 @@
+package game_models
+
+import (
+	"fmt"
+	"strings"
+)
+
+const MAX_TEAM_MONSTERS = 6
+
+/* A lineup that breaks a rule of the battle (mana cap, splinter, team size) */
+type TeamRuleError struct {
+	Reason string
+}
+
+func (e *TeamRuleError) Error() string {
+	return e.Reason
+}
+
+/* Rules every lineup of a battle must follow */
+type TeamRules struct {
+	// 0: no mana cap
+	ManaCap  int
+	Rulesets []Ruleset
+	Settings MatchSettings
+}
+
+/* Rules of a battle without match restrictions */
+func NewTeamRules(manaCap int, rulesets []Ruleset) TeamRules {
+	return TeamRules{ManaCap: manaCap, Rulesets: rulesets, Settings: NewMatchSettings()}
+}
+
+/*
+Tells if a monster of the color can be played with a summoner of the color.
+Gray monsters go with every summoner and dragon (gold) summoners can play monsters of any splinter.
+*/
+func IsColorCompatible(summonerColor, monsterColor CardColor) bool {
+	return monsterColor == COLOR_GRAY || monsterColor == summonerColor || summonerColor == COLOR_GOLD
+}
+
+/* Everything that makes the lineup illegal under the rules. Levels are played as given. */
+func (r TeamRules) ValidateBattleTeam(cardDetailMap CardDetailMap, battleTeam BattleTeam) []error {
+	errs := make([]error, 0)
+	ruleErr := func(reason string, args ...any) {
+		errs = append(errs, &TeamRuleError{Reason: fmt.Sprintf(reason, args...)})
+	}
+
+	summonerDetail, ok := cardDetailMap[battleTeam.Summoner.CardDetailID]
+	if !ok {
+		ruleErr("unknown summoner %d", battleTeam.Summoner.CardDetailID)
+		return errs
+	}
+	// a card played in the wrong role can't be set up either, it's only reported once
+	hasRoleError := false
+	if !IsSummonerCard(summonerDetail) {
+		ruleErr("%s is not a summoner", summonerDetail.Name)
+		hasRoleError = true
+	}
+	if len(battleTeam.Monsters) == 0 || len(battleTeam.Monsters) > MAX_TEAM_MONSTERS {
+		ruleErr("%d monsters out of 1-%d", len(battleTeam.Monsters), MAX_TEAM_MONSTERS)
+	}
+
+	// a dragon summoner plays a single other splinter
+	var dragonColor CardColor
+	seen := make(map[int]bool)
+	for _, m := range battleTeam.Monsters {
+		cardDetail, ok := cardDetailMap[m.CardDetailID]
+		if !ok {
+			ruleErr("unknown monster %d", m.CardDetailID)
+			return errs
+		}
+		if IsSummonerCard(cardDetail) {
+			ruleErr("%s is not a monster", cardDetail.Name)
+			hasRoleError = true
+		}
+		if seen[m.CardDetailID] {
+			ruleErr("%s is played twice", cardDetail.Name)
+		}
+		seen[m.CardDetailID] = true
+
+		if !IsColorCompatible(summonerDetail.Color, cardDetail.Color) {
+			ruleErr("%s (%s) can't be played with a %s summoner", cardDetail.Name, cardDetail.Color, summonerDetail.Color)
+		} else if summonerDetail.Color == COLOR_GOLD && cardDetail.Color != COLOR_GRAY && cardDetail.Color != COLOR_GOLD {
+			if dragonColor != "" && dragonColor != cardDetail.Color {
+				ruleErr("%s (%s) is of a second splinter with a dragon summoner", cardDetail.Name, cardDetail.Color)
+			}
+			dragonColor = cardDetail.Color
+		}
+	}
+
+	errs = append(errs, r.Settings.ValidateBattleTeam(cardDetailMap, battleTeam)...)
+
+	team, setupErrs := newGameTeam(cardDetailMap, battleTeam)
+	if !hasRoleError {
+		errs = append(errs, setupErrs...)
+	}
+	if r.ManaCap > 0 && team.GetManaCost() > r.ManaCap {
+		ruleErr("%d mana is over the mana cap of %d", team.GetManaCost(), r.ManaCap)
+	}
+	errs = append(errs, ValidateTeamForRulesets(r.Rulesets, team)...)
+	return errs
+}
+
+/* Summoner and monster ids in order, e.g. "167: 131, 91, 169" */
+func GetLineupKey(team BattleTeam) string {
+	ids := make([]string, 0, len(team.Monsters))
+	for _, m := range team.Monsters {
+		ids = append(ids, fmt.Sprint(m.CardDetailID))
+	}
+	return fmt.Sprintf("%d: %s", team.Summoner.CardDetailID, strings.Join(ids, ", "))
+}
+
+/* Summoners have flat stats, monsters have stats per level */
+func IsSummonerCard(cardDetail CardDetail) bool {
+	decoded, err := DecodeCardStats(cardDetail)
+	return err == nil && decoded.IsSummoner
+}
+
+/* The team and the errors of the cards that couldn't be set up */
+func newGameTeam(cardDetailMap CardDetailMap, battleTeam BattleTeam) (*GameTeam, []error) {
+	errs := make([]error, 0)
+	var summoner SummonerCard
+	if err := summoner.Setup(cardDetailMap[battleTeam.Summoner.CardDetailID], battleTeam.Summoner.Level); err != nil {
+		errs = append(errs, err)
+	}
+	monsterList := make([]*MonsterCard, 0, len(battleTeam.Monsters))
+	for _, m := range battleTeam.Monsters {
+		var monster MonsterCard
+		if err := monster.Setup(cardDetailMap[m.CardDetailID], m.Level); err != nil {
+			errs = append(errs, err)
+		}
+		monsterList = append(monsterList, &monster)
+	}
+	var team GameTeam
+	team.Create(&summoner, monsterList, battleTeam.Player)
+	return &team, errs
+}
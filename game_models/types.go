@@ -67,10 +67,11 @@ type Team struct {
 }
 
 type BattleDamage struct {
-	Attack           int // Some things care about if actually hit or not. Don't use this to check damage
-	DamageDone       int // Actual damage done after modifiers, overkills. So 10 dmg to a 1 health is still 10 dmg.
-	Remainder        int // Remainder damage after modifiers
-	ActualDamageDone int // Actual damage done after modifiers, but does not overkill. 10 dmg to a 1 health is 1 dmg.
+	Attack           int                // Some things care about if actually hit or not. Don't use this to check damage
+	DamageDone       int                // Actual damage done after modifiers, overkills. So 10 dmg to a 1 health is still 10 dmg.
+	Remainder        int                // Remainder damage after modifiers
+	ActualDamageDone int                // Actual damage done after modifiers, but does not overkill. 10 dmg to a 1 health is 1 dmg.
+	Calculation      *DamageCalculation // How the damage was reached. nil if the target was nil
 }
 
 type BattleHistory struct {
@@ -84,7 +85,7 @@ type BattleHistory struct {
 	Player1              string `json:"player_1"`
 	Player2              string `json:"player_2"`
 	CreatedDate          string `json:"created_date"`
-	ManaCap              int    `json:"created_block_num"`
+	ManaCap              int    `json:"mana_cap"`
 	Ruleset              string `json:"ruleset"`
 	Inactive             string `json:"inactive"`
 	Settings             string `json:"settings"`
@@ -0,0 +1,142 @@
+package game_models
+
+import (
+	"math"
+	"sort"
+)
+
+/* Parameters of the Elo fit of GetEloRatings */
+const (
+	// average rating of the teams
+	ELO_INITIAL_RATING = 1500
+	// rating difference where the better team is expected to score 10 times more
+	ELO_SCALE = 400
+	// step of a rating adjustment per matchup and iteration, small enough to converge without oscillating
+	ELO_K = 16
+	// adjustment passes over the matrix, enough for the ratings of a small round robin to settle
+	ELO_ITERATIONS = 200
+)
+
+/* Results of every team against every other team. [i][j] is from the point of view of team i. */
+type WinrateMatrix struct {
+	Teams []string
+	Wins  [][]int
+	Ties  [][]int
+	Games [][]int
+}
+
+/* Place of a team in a round robin. Ties count as half a win in the Elo rating. */
+type TeamRanking struct {
+	Index          int
+	Name           string
+	AverageWinrate float64
+	Elo            float64
+}
+
+func NewWinrateMatrix(teams []string) WinrateMatrix {
+	newTable := func() [][]int {
+		table := make([][]int, len(teams))
+		for i := range table {
+			table[i] = make([]int, len(teams))
+		}
+		return table
+	}
+	return WinrateMatrix{Teams: teams, Wins: newTable(), Ties: newTable(), Games: newTable()}
+}
+
+/* Records games of team i against team j, and the mirrored result of team j against team i */
+func (m *WinrateMatrix) AddResult(i, j, wins, ties, games int) {
+	m.Wins[i][j] += wins
+	m.Ties[i][j] += ties
+	m.Games[i][j] += games
+	if i == j {
+		return
+	}
+	m.Wins[j][i] += games - wins - ties
+	m.Ties[j][i] += ties
+	m.Games[j][i] += games
+}
+
+func (m WinrateMatrix) GetWinrate(i, j int) float64 {
+	if m.Games[i][j] == 0 {
+		return 0
+	}
+	return float64(m.Wins[i][j]) / float64(m.Games[i][j])
+}
+
+func (m WinrateMatrix) GetTieRate(i, j int) float64 {
+	if m.Games[i][j] == 0 {
+		return 0
+	}
+	return float64(m.Ties[i][j]) / float64(m.Games[i][j])
+}
+
+/* Win rate of team i averaged over the other teams it played */
+func (m WinrateMatrix) GetAverageWinrate(i int) float64 {
+	total := 0.0
+	opponents := 0
+	for j := range m.Teams {
+		if i == j || m.Games[i][j] == 0 {
+			continue
+		}
+		total += m.GetWinrate(i, j)
+		opponents++
+	}
+	if opponents == 0 {
+		return 0
+	}
+	return total / float64(opponents)
+}
+
+/*
+Elo ratings that fit the matrix: the ratings are adjusted until the expected score of every matchup
+is close to its score (wins + half the ties). Ratings average ELO_INITIAL_RATING.
+*/
+func (m WinrateMatrix) GetEloRatings() []float64 {
+	ratings := make([]float64, len(m.Teams))
+	for i := range ratings {
+		ratings[i] = ELO_INITIAL_RATING
+	}
+
+	for iteration := 0; iteration < ELO_ITERATIONS; iteration++ {
+		adjustments := make([]float64, len(m.Teams))
+		for i := range m.Teams {
+			for j := range m.Teams {
+				if i == j || m.Games[i][j] == 0 {
+					continue
+				}
+				score := (float64(m.Wins[i][j]) + float64(m.Ties[i][j])/2) / float64(m.Games[i][j])
+				expected := 1 / (1 + math.Pow(10, (ratings[j]-ratings[i])/ELO_SCALE))
+				adjustments[i] += ELO_K * (score - expected)
+			}
+		}
+		for i := range ratings {
+			ratings[i] += adjustments[i]
+		}
+	}
+
+	if len(ratings) > 0 {
+		mean := 0.0
+		for _, r := range ratings {
+			mean += r
+		}
+		mean /= float64(len(ratings))
+		for i := range ratings {
+			ratings[i] += ELO_INITIAL_RATING - mean
+		}
+	}
+	return ratings
+}
+
+/* Teams sorted by Elo rating, best first */
+func (m WinrateMatrix) GetRankings() []TeamRanking {
+	ratings := m.GetEloRatings()
+	rankings := make([]TeamRanking, 0, len(m.Teams))
+	for i, name := range m.Teams {
+		rankings = append(rankings, TeamRanking{Index: i, Name: name, AverageWinrate: m.GetAverageWinrate(i), Elo: ratings[i]})
+	}
+	sort.SliceStable(rankings, func(i, j int) bool {
+		return rankings[i].Elo > rankings[j].Elo
+	})
+	return rankings
+}
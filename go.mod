@@ -6,10 +6,13 @@ replace github.com/YukiUmetsu/go-spl-simulator/game_utils => ./game_utils
 
 replace github.com/YukiUmetsu/go-spl-simulator/game_models => ./game_models
 
+require (
+	github.com/stretchr/testify v1.8.0
+	golang.org/x/exp v0.0.0-20220613132600-b0d781184e0d
+	gopkg.in/yaml.v3 v3.0.1
+)
+
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.8.0 // indirect
-	golang.org/x/exp v0.0.0-20220613132600-b0d781184e0d // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,36 @@
+package simulator
+
+import (
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+)
+
+/* Same as CreateGameTeam but the monster levels are capped by the summoner level */
+func CreateCappedGameTeam(cardDetailMap CardDetailMap, battleTeam BattleTeam) (*GameTeam, []LevelCapAdjustment, error) {
+	cappedTeam, adjustments := ApplySummonerLevelCap(cardDetailMap, battleTeam)
+	gameTeam, err := CreateGameTeam(cardDetailMap, cappedTeam)
+	return gameTeam, adjustments, err
+}
+
+/* Same as CreateGame but the monster levels of both teams are capped by their summoner level */
+func CreateCappedGame(cardDetailMap CardDetailMap, battleDetails BattleDetails, rulesets []Ruleset, shouldLog bool) (Game, []LevelCapAdjustment, []LevelCapAdjustment, error) {
+	var game Game
+	gameTeam1, team1Adjustments, err := CreateCappedGameTeam(cardDetailMap, battleDetails.Team1)
+	if err != nil {
+		return game, nil, nil, err
+	}
+	gameTeam2, team2Adjustments, err := CreateCappedGameTeam(cardDetailMap, battleDetails.Team2)
+	if err != nil {
+		return game, nil, nil, err
+	}
+	game.Create(gameTeam1, gameTeam2, rulesets, shouldLog)
+	return game, team1Adjustments, team2Adjustments, nil
+}
+
+/* Same as CreateGame but both teams are capped by the rating level of the match */
+func CreateMatchGame(cardDetailMap CardDetailMap, battleDetails BattleDetails, rulesets []Ruleset, settings MatchSettings, shouldLog bool) (Game, error) {
+	team1, _ := ApplyMatchLevelCap(cardDetailMap, battleDetails.Team1, settings)
+	team2, _ := ApplyMatchLevelCap(cardDetailMap, battleDetails.Team2, settings)
+	battleDetails.Team1 = team1
+	battleDetails.Team2 = team2
+	return CreateGame(cardDetailMap, battleDetails, rulesets, shouldLog)
+}
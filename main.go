@@ -35,6 +35,9 @@ func simulateMainBattle(battleId string, isforWinrate bool, playerNum int) {
 		return
 	}
 
-	winRate, playerName := GetWinrateOfBattle(battleId, playerNum)
+	winRate, playerName, err := GetWinrateOfBattle(battleId, playerNum)
+	if err != nil {
+		log.Fatalln("final error: ", err)
+	}
 	fmt.Printf("player: %s, winrate: %f\n", playerName, winRate)
 }
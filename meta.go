@@ -0,0 +1,61 @@
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+)
+
+/*
+Aggregates the battles into play and win rates of every summoner, monster and monster pairing per ruleset and mana bracket.
+Battles with malformed details are skipped and returned as errors.
+*/
+func GetMetaReport(cardDetailMap CardDetailMap, historicBattles []BattleHistory) (MetaReport, []error) {
+	stats := NewMetaStats(cardDetailMap)
+	errs := make([]error, 0)
+	for _, historicBattle := range historicBattles {
+		var battleDetails BattleDetails
+		if err := json.Unmarshal([]byte(historicBattle.Details), &battleDetails); err != nil {
+			errs = append(errs, fmt.Errorf("battle %s: %w", historicBattle.BattleQueueId1, err))
+			continue
+		}
+		stats.AddBattle(MetaBattle{
+			ManaCap:  historicBattle.ManaCap,
+			Rulesets: ParseRulesets(historicBattle.Ruleset),
+			Teams: []MetaTeam{
+				{Team: battleDetails.Team1, Rating: historicBattle.Player1RatingInitial, Won: historicBattle.Winner == historicBattle.Player1},
+				{Team: battleDetails.Team2, Rating: historicBattle.Player2RatingInitial, Won: historicBattle.Winner == historicBattle.Player2},
+			},
+		})
+	}
+	return stats.GetReport(), errs
+}
+
+/* Writes the report as JSON and CSV. An empty path skips that format. */
+func SaveMetaReport(report MetaReport, jsonPath, csvPath string) error {
+	for _, output := range []struct {
+		path  string
+		write func(f *os.File) error
+	}{
+		{jsonPath, func(f *os.File) error { return report.WriteJSON(f) }},
+		{csvPath, func(f *os.File) error { return report.WriteCSV(f) }},
+	} {
+		if output.path == "" {
+			continue
+		}
+		f, err := os.Create(output.path)
+		if err != nil {
+			return err
+		}
+		err = output.write(f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+)
+
+/* An opponent team and how likely it is to be faced */
+type WeightedOpponent struct {
+	Name   string
+	Team   BattleTeam
+	Weight float64
+}
+
+type OptimizerOptions struct {
+	Rules TeamRules
+	// random lineups screened, 200 by default
+	Candidates int
+	// best screened lineups simulated again with Iterations games, 10 by default
+	Finalists int
+	// games per opponent to screen a lineup, 10 by default
+	ScreeningIterations int
+	// games per opponent of the finalists, 100 by default
+	Iterations int
+	// 0: one per CPU
+	Workers int
+	Seed    int64
+}
+
+func (o OptimizerOptions) withDefaults() OptimizerOptions {
+	if o.Candidates <= 0 {
+		o.Candidates = 200
+	}
+	if o.Finalists <= 0 {
+		o.Finalists = 10
+	}
+	if o.ScreeningIterations <= 0 {
+		o.ScreeningIterations = 10
+	}
+	if o.Iterations <= 0 {
+		o.Iterations = 100
+	}
+	return o
+}
+
+/* A lineup found by the optimizer. Ties count as losses. */
+type OptimizedLineup struct {
+	Team BattleTeam
+	// win rate averaged over the opponents by weight
+	ExpectedWinrate float64
+	// lowest win rate against a single opponent
+	WorstCaseWinrate  float64
+	WorstCaseOpponent string
+	// win rate against each opponent, in the order of the opponents
+	Winrates []WinrateEstimate
+}
+
+/* Merges identical teams (e.g. from a player's recent battles) into opponents weighted by how often they were played */
+func GetWeightedOpponents(teams []BattleTeam) []WeightedOpponent {
+	opponents := make([]WeightedOpponent, 0)
+	indexes := make(map[string]int)
+	for _, team := range teams {
+		key := GetLineupKey(team)
+		if i, ok := indexes[key]; ok {
+			opponents[i].Weight += 1
+			continue
+		}
+		indexes[key] = len(opponents)
+		opponents = append(opponents, WeightedOpponent{Name: key, Team: team, Weight: 1})
+	}
+	return opponents
+}
+
+/*
+Searches the pool for the lineups with the best expected win rate against the opponents, best first.
+Random legal lineups are screened with a few games, then the best ones are simulated again with more games.
+Lineups follow the rules (mana cap, rulesets, match settings, splinters) and are played with their level caps applied.
+*/
+func OptimizeTeam(cardDetailMap CardDetailMap, pool CardPool, player string, opponents []WeightedOpponent, options OptimizerOptions) ([]OptimizedLineup, error) {
+	options = options.withDefaults()
+	totalWeight := 0.0
+	for _, opponent := range opponents {
+		if opponent.Weight < 0 {
+			return nil, fmt.Errorf("opponent %s: negative weight %f", opponent.Name, opponent.Weight)
+		}
+		cappedTeam, _ := ApplyMatchLevelCap(cardDetailMap, opponent.Team, options.Rules.Settings)
+		if _, err := CreateGameTeam(cardDetailMap, cappedTeam); err != nil {
+			return nil, fmt.Errorf("opponent %s: %w", opponent.Name, err)
+		}
+		totalWeight += opponent.Weight
+	}
+	if totalWeight <= 0 {
+		return nil, errors.New("no opponent with a positive weight")
+	}
+
+	pool = pool.FilterByMatchSettings(cardDetailMap, options.Rules.Settings)
+	rng := rand.New(rand.NewSource(options.Seed))
+	lineups := generateLineups(cardDetailMap, pool, player, options.Rules, options.Candidates, rng)
+	if len(lineups) == 0 {
+		return nil, errors.New("no legal lineup in the pool")
+	}
+
+	screened := evaluateLineups(cardDetailMap, lineups, opponents, options.Rules, options.ScreeningIterations, options.Workers)
+	if len(screened) > options.Finalists {
+		screened = screened[:options.Finalists]
+	}
+	finalists := make([]BattleTeam, 0, len(screened))
+	for _, lineup := range screened {
+		finalists = append(finalists, lineup.Team)
+	}
+	return evaluateLineups(cardDetailMap, finalists, opponents, options.Rules, options.Iterations, options.Workers), nil
+}
+
+/* Same as OptimizeTeam against a single known opponent */
+func OptimizeTeamAgainst(cardDetailMap CardDetailMap, pool CardPool, player string, opponent BattleTeam, options OptimizerOptions) ([]OptimizedLineup, error) {
+	return OptimizeTeam(cardDetailMap, pool, player, []WeightedOpponent{{Name: GetLineupKey(opponent), Team: opponent, Weight: 1}}, options)
+}
+
+/* Plays every lineup against every opponent and returns the lineups sorted by expected win rate, then worst case */
+func evaluateLineups(cardDetailMap CardDetailMap, lineups []BattleTeam, opponents []WeightedOpponent, rules TeamRules, iterations, workers int) []OptimizedLineup {
+	createGame := func(battleDetails BattleDetails) Game {
+		// the cards of the lineups and the opponents were set up without errors by OptimizeTeam
+		game, _ := CreateMatchGame(cardDetailMap, battleDetails, rules.Rulesets, rules.Settings, false)
+		return game
+	}
+	wins := make([]int, len(lineups)*len(opponents))
+	runInParallel(workers, len(wins), func(index int) {
+		lineup := lineups[index/len(opponents)]
+		opponent := opponents[index%len(opponents)]
+		wins[index], _ = playMatchup(createGame, lineup, opponent.Team, iterations)
+	})
+
+	results := make([]OptimizedLineup, 0, len(lineups))
+	for i, lineup := range lineups {
+		result := OptimizedLineup{Team: lineup, Winrates: make([]WinrateEstimate, 0, len(opponents))}
+		totalWeight := 0.0
+		hasWorstCase := false
+		for j, opponent := range opponents {
+			winrate := NewWinrateEstimate(wins[i*len(opponents)+j], iterations)
+			result.Winrates = append(result.Winrates, winrate)
+			result.ExpectedWinrate += winrate.Winrate * opponent.Weight
+			totalWeight += opponent.Weight
+			if opponent.Weight > 0 && (!hasWorstCase || winrate.Winrate < result.WorstCaseWinrate) {
+				hasWorstCase = true
+				result.WorstCaseWinrate = winrate.Winrate
+				result.WorstCaseOpponent = opponent.Name
+			}
+		}
+		result.ExpectedWinrate /= totalWeight
+		results = append(results, result)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].ExpectedWinrate != results[j].ExpectedWinrate {
+			return results[i].ExpectedWinrate > results[j].ExpectedWinrate
+		}
+		return results[i].WorstCaseWinrate > results[j].WorstCaseWinrate
+	})
+	return results
+}
+
+/*
+Up to count distinct random legal lineups: a random summoner with monsters of the pool added in random order
+(and so in random positions) as long as the lineup stays legal. Monster levels are capped by the summoner level.
+*/
+func generateLineups(cardDetailMap CardDetailMap, pool CardPool, player string, rules TeamRules, count int, rng *rand.Rand) []BattleTeam {
+	summoners := make([]CollectionCard, 0)
+	monsters := make([]CollectionCard, 0)
+	for _, card := range pool.GetCards() {
+		if IsSummonerCard(cardDetailMap[card.CardDetailID]) {
+			summoners = append(summoners, card)
+		} else {
+			monsters = append(monsters, card)
+		}
+	}
+	if len(summoners) == 0 || len(monsters) == 0 {
+		return []BattleTeam{}
+	}
+
+	isLegal := func(team BattleTeam) bool {
+		return len(rules.ValidateBattleTeam(cardDetailMap, team)) == 0
+	}
+	lineups := make([]BattleTeam, 0, count)
+	seen := make(map[string]bool)
+	for attempt := 0; attempt < count*10 && len(lineups) < count; attempt++ {
+		team := BattleTeam{Summoner: summoners[rng.Intn(len(summoners))], Player: player, Monsters: make([]CollectionCard, 0, MAX_TEAM_MONSTERS)}
+		for _, i := range rng.Perm(len(monsters)) {
+			if len(team.Monsters) == MAX_TEAM_MONSTERS {
+				break
+			}
+			candidate := team
+			candidate.Monsters = append(append([]CollectionCard{}, team.Monsters...), monsters[i])
+			candidate, _ = ApplySummonerLevelCap(cardDetailMap, candidate)
+			if isLegal(candidate) {
+				team = candidate
+			}
+		}
+
+		key := GetLineupKey(team)
+		if len(team.Monsters) == 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		lineups = append(lineups, team)
+	}
+	return lineups
+}
@@ -0,0 +1,41 @@
+package simulator
+
+import (
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+)
+
+/* Win rate of a team with the unmodified catalog and with an overlay applied */
+type OverlayComparison struct {
+	Overlay  string
+	Baseline WinrateEstimate
+	Modified WinrateEstimate
+	// Modified - Baseline win rate and its 95% confidence bounds
+	Delta      float64
+	DeltaLower float64
+	DeltaUpper float64
+}
+
+/* Tells if the overlay changes the win rate with 95% confidence */
+func (c OverlayComparison) IsSignificant() bool {
+	return c.DeltaLower > 0 || c.DeltaUpper < 0
+}
+
+/* Simulates the team against every opponent with the unmodified catalog and with the overlay applied */
+func CompareCardOverlay(cardDetailMap CardDetailMap, overlay CardOverlay, team BattleTeam, opponents []BattleTeam, rulesets []Ruleset, iterationsPerOpponent int) (OverlayComparison, error) {
+	overlaidCardDetailMap, err := overlay.Apply(cardDetailMap)
+	if err != nil {
+		return OverlayComparison{}, err
+	}
+
+	comparison := OverlayComparison{Overlay: overlay.Name}
+	comparison.Baseline, err = EstimateWinrateAgainstOpponents(cardDetailMap, team, opponents, rulesets, iterationsPerOpponent)
+	if err != nil {
+		return OverlayComparison{}, err
+	}
+	comparison.Modified, err = EstimateWinrateAgainstOpponents(overlaidCardDetailMap, team, opponents, rulesets, iterationsPerOpponent)
+	if err != nil {
+		return OverlayComparison{}, err
+	}
+	comparison.Delta, comparison.DeltaLower, comparison.DeltaUpper = comparison.Modified.GetDeltaBounds(comparison.Baseline)
+	return comparison, nil
+}
@@ -0,0 +1,54 @@
+package simulator
+
+import (
+	"runtime"
+	"sync"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+)
+
+/* Calls run for every index in 0..count-1 on a pool of workers (0: one per CPU) and waits for all of them */
+func runInParallel(workers, count int, run func(index int)) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				run(index)
+			}
+		}()
+	}
+	for index := 0; index < count; index++ {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+/* Wins and ties of team1 over the games, alternating between playing as team 1 (even games) and as team 2 (odd games) */
+func playMatchup(createGame func(battleDetails BattleDetails) Game, team1, team2 BattleTeam, iterations int) (int, int) {
+	wins := 0
+	ties := 0
+	for k := 0; k < iterations; k++ {
+		battleDetails := BattleDetails{Team1: team1, Team2: team2}
+		team := TEAM_NUM_ONE
+		if k%2 == 1 {
+			battleDetails = BattleDetails{Team1: team2, Team2: team1}
+			team = TEAM_NUM_TWO
+		}
+		game := createGame(battleDetails)
+		game.PlayGame()
+		switch game.GetWinner() {
+		case team:
+			wins++
+		case TEAM_NUM_TIE:
+			ties++
+		}
+	}
+	return wins, ties
+}
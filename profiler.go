@@ -0,0 +1,113 @@
+package simulator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+)
+
+/* Parses every .json file of the directory, each holding a battle history or a list of them */
+func LoadBattleHistories(dir string) ([]BattleHistory, error) {
+	histories := make([]BattleHistory, 0)
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return histories, err
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return histories, err
+		}
+		data = bytes.TrimSpace(data)
+		if len(data) > 0 && data[0] == '[' {
+			var list []BattleHistory
+			if err := json.Unmarshal(data, &list); err != nil {
+				return histories, fmt.Errorf("%s: %w", path, err)
+			}
+			histories = append(histories, list...)
+			continue
+		}
+		var history BattleHistory
+		if err := json.Unmarshal(data, &history); err != nil {
+			return histories, fmt.Errorf("%s: %w", path, err)
+		}
+		histories = append(histories, history)
+	}
+	return histories, nil
+}
+
+/* Rules the teams of the historic battle had to follow: mana cap, rulesets, match settings and inactive splinters */
+func ParseTeamRules(historicBattle BattleHistory) (TeamRules, error) {
+	rules := NewTeamRules(historicBattle.ManaCap, ParseRulesets(historicBattle.Ruleset))
+	settings, err := ParseMatchSettings(historicBattle)
+	if err != nil {
+		return rules, err
+	}
+	rules.Settings = settings
+	return rules, nil
+}
+
+/*
+Profiles the teams the player played in the battles. Battles the player didn't play are skipped
+and battles with malformed details are skipped and returned as errors.
+*/
+func ProfilePlayer(cardDetailMap CardDetailMap, player string, historicBattles []BattleHistory) (OpponentProfile, []error) {
+	profile := NewOpponentProfile(player)
+	battles := make([]ProfiledBattle, 0)
+	errs := make([]error, 0)
+	for _, historicBattle := range historicBattles {
+		if historicBattle.Player1 != player && historicBattle.Player2 != player {
+			continue
+		}
+		var battleDetails BattleDetails
+		if err := json.Unmarshal([]byte(historicBattle.Details), &battleDetails); err != nil {
+			errs = append(errs, fmt.Errorf("battle %s: %w", historicBattle.BattleQueueId1, err))
+			continue
+		}
+
+		team := battleDetails.Team1
+		if historicBattle.Player2 == player {
+			team = battleDetails.Team2
+		}
+		// dragon summoners are played in the splinter of the team
+		color := CardColor(team.Color)
+		if color == "" {
+			color = cardDetailMap[team.Summoner.CardDetailID].Color
+		}
+		battles = append(battles, ProfiledBattle{
+			CreatedDate: historicBattle.CreatedDate,
+			ManaCap:     historicBattle.ManaCap,
+			Rulesets:    ParseRulesets(historicBattle.Ruleset),
+			Color:       color,
+			Team:        team,
+			Won:         historicBattle.Winner == player,
+		})
+	}
+	profile.AddBattles(battles)
+	return profile, errs
+}
+
+/* Same as ProfilePlayer with the battles saved in the directory */
+func ProfilePlayerFromDir(cardDetailMap CardDetailMap, player string, dir string) (OpponentProfile, []error) {
+	historicBattles, err := LoadBattleHistories(dir)
+	if err != nil {
+		return NewOpponentProfile(player), []error{err}
+	}
+	return ProfilePlayer(cardDetailMap, player, historicBattles)
+}
+
+/* The teams the player is likely to play in a match with the rules, weighted by probability, to optimize a team against */
+func GetLikelyOpponents(cardDetailMap CardDetailMap, profile OpponentProfile, rules TeamRules) []WeightedOpponent {
+	opponents := make([]WeightedOpponent, 0)
+	for _, prediction := range profile.PredictTeams(cardDetailMap, rules) {
+		opponents = append(opponents, WeightedOpponent{Name: GetLineupKey(prediction.Team), Team: prediction.Team, Weight: prediction.Probability})
+	}
+	return opponents
+}
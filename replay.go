@@ -0,0 +1,40 @@
+package simulator
+
+import (
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+)
+
+/* Plays the battle and returns the game with the tape of its random draws */
+func RecordBattle(cardDetailMap CardDetailMap, battleDetails BattleDetails, rulesets []Ruleset) (*Game, *RandomTape, error) {
+	game, err := CreateGame(cardDetailMap, battleDetails, rulesets, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	tape := RecordGame(&game)
+	return &game, tape, nil
+}
+
+/* Plays the battle again with the recorded draws and the overrides */
+func ReplayBattle(cardDetailMap CardDetailMap, battleDetails BattleDetails, rulesets []Ruleset, recorded []RandomDraw, overrides ...RandomOverride) (*Game, *RandomTape, error) {
+	createGame, err := createBattleGame(cardDetailMap, battleDetails, rulesets)
+	if err != nil {
+		return nil, nil, err
+	}
+	game, tape := ReplayGame(createGame, recorded, overrides...)
+	return game, tape, nil
+}
+
+/* The recorded draws of the battle changing its winner with another outcome */
+func FindPivotalBattleDraws(cardDetailMap CardDetailMap, battleDetails BattleDetails, rulesets []Ruleset, recorded []RandomDraw) ([]PivotalDraw, error) {
+	createGame, err := createBattleGame(cardDetailMap, battleDetails, rulesets)
+	if err != nil {
+		return nil, err
+	}
+	return FindPivotalDraws(createGame, recorded), nil
+}
+
+func createBattleGame(cardDetailMap CardDetailMap, battleDetails BattleDetails, rulesets []Ruleset) (func() *Game, error) {
+	return newGameFactory(func() (Game, error) {
+		return CreateGame(cardDetailMap, battleDetails, rulesets, false)
+	})
+}
@@ -0,0 +1,79 @@
+package simulator
+
+import (
+	"fmt"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+)
+
+/* A team taking part in a round robin */
+type TeamSpec struct {
+	Name string
+	Team BattleTeam
+}
+
+type RoundRobinOptions struct {
+	// rules every team must follow, the same as the lineups of the optimizer
+	Rules TeamRules
+	// games per pair of teams
+	Iterations int
+	// 0: one per CPU
+	Workers int
+}
+
+type RoundRobinResult struct {
+	Matrix WinrateMatrix
+	// Winrates[i][j]: win rate of team i against team j with its 95% confidence bounds
+	Winrates [][]WinrateEstimate
+	Rankings []TeamRanking
+}
+
+/*
+Plays every team against every other team in parallel and returns the win rate matrix and the rankings.
+Each pair plays half of the games on each side. Teams are played with their level caps applied
+and teams breaking the rules (mana cap, rulesets, match settings, splinters) are rejected.
+*/
+func RunRoundRobin(cardDetailMap CardDetailMap, teams []TeamSpec, options RoundRobinOptions) (RoundRobinResult, error) {
+	names := make([]string, 0, len(teams))
+	cappedTeams := make([]BattleTeam, 0, len(teams))
+	for _, spec := range teams {
+		team, _ := ApplyTeamLevelCaps(cardDetailMap, spec.Team, options.Rules.Settings)
+		if errs := options.Rules.ValidateBattleTeam(cardDetailMap, team); len(errs) > 0 {
+			return RoundRobinResult{}, fmt.Errorf("team %s: %w", spec.Name, errs[0])
+		}
+		names = append(names, spec.Name)
+		cappedTeams = append(cappedTeams, team)
+	}
+
+	pairs := make([][2]int, 0)
+	for i := range teams {
+		for j := i + 1; j < len(teams); j++ {
+			pairs = append(pairs, [2]int{i, j})
+		}
+	}
+	wins := make([]int, len(pairs))
+	ties := make([]int, len(pairs))
+	createGame := func(battleDetails BattleDetails) Game {
+		// the teams were validated above, their cards are set up without errors
+		game, _ := CreateGame(cardDetailMap, battleDetails, options.Rules.Rulesets, false)
+		return game
+	}
+	runInParallel(options.Workers, len(pairs), func(index int) {
+		pair := pairs[index]
+		wins[index], ties[index] = playMatchup(createGame, cappedTeams[pair[0]], cappedTeams[pair[1]], options.Iterations)
+	})
+
+	matrix := NewWinrateMatrix(names)
+	for index, pair := range pairs {
+		matrix.AddResult(pair[0], pair[1], wins[index], ties[index], options.Iterations)
+	}
+
+	winrates := make([][]WinrateEstimate, len(teams))
+	for i := range teams {
+		winrates[i] = make([]WinrateEstimate, len(teams))
+		for j := range teams {
+			winrates[i][j] = NewWinrateEstimate(matrix.Wins[i][j], matrix.Games[i][j])
+		}
+	}
+	return RoundRobinResult{Matrix: matrix, Winrates: winrates, Rankings: matrix.GetRankings()}, nil
+}
@@ -17,44 +17,55 @@ const BATTLE_HISTORY_ENDPOINT = "battle/result?id="
 
 /*  Creates the game using the card id. Returns the battle logs. */
 func SimulateBattle(battleId string, shouldLog bool) ([]BattleLog, error) {
-	cardDetailMap := GetAllCardDetail()
+	cardDetailMap, _ := GetAllCardDetail()
 	historicBattle := GetHistoricBattle(battleId)
 
-	var battleDetails BattleDetails
-	err := json.Unmarshal([]byte(historicBattle.Details), &battleDetails)
+	battleDetails := ParseBattleDetails(historicBattle)
+	rulesets := ParseRulesets(historicBattle.Ruleset)
+	game, err := CreateGame(cardDetailMap, battleDetails, rulesets, shouldLog)
 	if err != nil {
-		log.Fatalln(err)
+		return nil, err
 	}
+	game.PlayGame()
+	return game.GetBattleLogs()
+}
 
-	rulesetStrArr := strings.Split(historicBattle.Ruleset, "|")
-	rulesets := make([]Ruleset, 0)
-	for _, rulesetStr := range rulesetStrArr {
-		rulesets = append(rulesets, Ruleset(rulesetStr))
+/* Same as SimulateBattle but plays the battle with the rules of the registered version. An empty version name uses the current rules. */
+func SimulateBattleWithRulesVersion(battleId string, shouldLog bool, versionName string) ([]BattleLog, error) {
+	cardDetailMap, _ := GetAllCardDetail()
+	historicBattle := GetHistoricBattle(battleId)
+
+	if versionName == "" {
+		versionName = RULES_VERSION_CURRENT
+	}
+	version, err := GetRulesVersion(versionName)
+	if err != nil {
+		return nil, err
+	}
+	game, err := CreateGameWithRulesVersion(cardDetailMap, ParseBattleDetails(historicBattle), ParseRulesets(historicBattle.Ruleset), shouldLog, version)
+	if err != nil {
+		return nil, err
 	}
-	game := CreateGame(cardDetailMap, battleDetails, rulesets, shouldLog)
 	game.PlayGame()
 	return game.GetBattleLogs()
 }
 
-func GetWinrateOfBattle(battleId string, playerNum int) (float64, string) {
+func GetWinrateOfBattle(battleId string, playerNum int) (float64, string, error) {
 	winCount := 0
-	cardDetailMap := GetAllCardDetail()
+	cardDetailMap, _ := GetAllCardDetail()
 	historicBattle := GetHistoricBattle(battleId)
 
-	var battleDetails BattleDetails
-	err := json.Unmarshal([]byte(historicBattle.Details), &battleDetails)
+	battleDetails := ParseBattleDetails(historicBattle)
+	rulesets := ParseRulesets(historicBattle.Ruleset)
+	createGame, err := newGameFactory(func() (Game, error) {
+		return CreateGame(cardDetailMap, battleDetails, rulesets, false)
+	})
 	if err != nil {
-		log.Fatalln(err)
-	}
-
-	rulesetStrArr := strings.Split(historicBattle.Ruleset, "|")
-	rulesets := make([]Ruleset, 0)
-	for _, rulesetStr := range rulesetStrArr {
-		rulesets = append(rulesets, Ruleset(rulesetStr))
+		return 0, "", err
 	}
 
 	for i := 0; i < 100; i++ {
-		game := CreateGame(cardDetailMap, battleDetails, rulesets, false)
+		game := createGame()
 		game.PlayGame()
 		winner := game.GetWinner()
 		if int(winner) == playerNum {
@@ -68,10 +79,77 @@ func GetWinrateOfBattle(battleId string, playerNum int) (float64, string) {
 	} else {
 		playerName = battleDetails.Team2.Player
 	}
-	return math.Round(float64(winCount) * 100 / 100), playerName
+	return math.Round(float64(winCount) * 100 / 100), playerName, nil
+}
+
+/* Parses the team details of the historic battle */
+func ParseBattleDetails(historicBattle BattleHistory) BattleDetails {
+	var battleDetails BattleDetails
+	err := json.Unmarshal([]byte(historicBattle.Details), &battleDetails)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return battleDetails
+}
+
+/* Parses rulesets separated by "|" (e.g. "Silenced Summoners|Earthquake") */
+func ParseRulesets(rulesetStr string) []Ruleset {
+	rulesets := make([]Ruleset, 0)
+	for _, r := range strings.Split(rulesetStr, "|") {
+		if r == "" {
+			continue
+		}
+		rulesets = append(rulesets, Ruleset(r))
+	}
+	return rulesets
+}
+
+/* settings of a brawl or tournament battle (BattleHistory.Settings) */
+type battleSettings struct {
+	RatingLevel  *int               `json:"rating_level"`
+	AllowedCards BattleAllowedCards `json:"allowed_cards"`
+}
+
+/* Parses the settings and inactive splinters of the historic battle. Unknown foil or card type restrictions are returned as an error. */
+func ParseMatchSettings(historicBattle BattleHistory) (MatchSettings, error) {
+	settings := NewMatchSettings()
+	for _, color := range strings.Split(historicBattle.Inactive, ",") {
+		color = strings.TrimSpace(color)
+		if color != "" {
+			settings.InactiveColors = append(settings.InactiveColors, CardColor(color))
+		}
+	}
+	if historicBattle.Settings == "" {
+		return settings, nil
+	}
+
+	var bs battleSettings
+	if err := json.Unmarshal([]byte(historicBattle.Settings), &bs); err != nil {
+		return settings, err
+	}
+	if bs.RatingLevel != nil {
+		settings.RatingLevel = *bs.RatingLevel
+	}
+	if len(bs.AllowedCards.Editions) > 0 {
+		settings.Format = NewCustomFormat("Allowed Editions", bs.AllowedCards)
+	}
+	if bs.AllowedCards.Foil != "" {
+		settings.AllowedFoil = bs.AllowedCards.Foil
+	}
+	if bs.AllowedCards.Type != "" {
+		settings.AllowedType = bs.AllowedCards.Type
+	}
+	if settings.AllowedFoil != ALLOWED_FOIL_ALL && settings.AllowedFoil != ALLOWED_FOIL_GOLD_ONLY {
+		return settings, fmt.Errorf("unknown allowed foil %q", settings.AllowedFoil)
+	}
+	if settings.AllowedType != ALLOWED_TYPE_ALL && settings.AllowedType != ALLOWED_TYPE_NO_LEGENDARIES {
+		return settings, fmt.Errorf("unknown allowed card type %q", settings.AllowedType)
+	}
+	return settings, nil
 }
 
-func GetAllCardDetail() CardDetailMap {
+/* Official cards. Malformed cards are left out and returned as errors, games with them fail to be created with an unknown card error */
+func GetAllCardDetail() (CardDetailMap, []error) {
 	resp, err := http.Get(SPL_API_URL + GET_ALL_CARDS_ENDPOIONT)
 	if err != nil {
 		log.Fatalln(err)
@@ -84,14 +162,26 @@ func GetAllCardDetail() CardDetailMap {
 		log.Fatal(err)
 	}
 
-	cardDetailMap := make(CardDetailMap)
-	for _, cd := range cardDetails {
-		cardDetailMap[cd.ID] = cd
+	return DecodeCardDetails(cardDetails)
+}
+
+/*
+Official cards with the custom cards of the file (JSON or YAML) added.
+Malformed official cards and custom cards that couldn't be added are left out and returned as errors,
+the error is returned when the file can't be loaded.
+*/
+func GetAllCardDetailWithCustomCards(path string) (CardDetailMap, []error, error) {
+	definitions, err := LoadCustomCards(path)
+	if err != nil {
+		return nil, nil, err
 	}
-	return cardDetailMap
+	cardDetailMap, decodeErrors := GetAllCardDetail()
+	cardDetailMap, mergeErrors := MergeCustomCards(cardDetailMap, definitions)
+	return cardDetailMap, append(decodeErrors, mergeErrors...), nil
 }
 
-func GetAllCardDetailPerCardName() CardDetailMapPerName {
+/* Same as GetAllCardDetail with the cards by name */
+func GetAllCardDetailPerCardName() (CardDetailMapPerName, []error) {
 	resp, err := http.Get(SPL_API_URL + GET_ALL_CARDS_ENDPOIONT)
 	if err != nil {
 		log.Fatalln(err)
@@ -104,11 +194,12 @@ func GetAllCardDetailPerCardName() CardDetailMapPerName {
 		log.Fatal(err)
 	}
 
+	validCardDetails, decodeErrors := DecodeCardDetails(cardDetails)
 	cardDetailMap := make(CardDetailMapPerName)
-	for _, cd := range cardDetails {
+	for _, cd := range validCardDetails {
 		cardDetailMap[cd.Name] = cd
 	}
-	return cardDetailMap
+	return cardDetailMap, decodeErrors
 }
 
 func GetHistoricBattle(battleID string) BattleHistory {
@@ -127,29 +218,100 @@ func GetHistoricBattle(battleID string) BattleHistory {
 	return bh
 }
 
-func CreateGameTeam(cardDetailMap CardDetailMap, battleTeam BattleTeam) *GameTeam {
+/* The team of the battle. Returns a *CardDecodeError if a card is unknown or can't be set up */
+func CreateGameTeam(cardDetailMap CardDetailMap, battleTeam BattleTeam) (*GameTeam, error) {
+	getCardDetail := func(card CollectionCard) (CardDetail, error) {
+		cardDetail, ok := cardDetailMap[card.CardDetailID]
+		if !ok {
+			return cardDetail, &CardDecodeError{CardDetailID: card.CardDetailID, Field: "id", Reason: "unknown card"}
+		}
+		return cardDetail, nil
+	}
+
 	var summoner SummonerCard
-	summonerDetail := cardDetailMap[battleTeam.Summoner.CardDetailID]
-	summoner.Setup(summonerDetail, battleTeam.Summoner.Level)
+	summonerDetail, err := getCardDetail(battleTeam.Summoner)
+	if err != nil {
+		return nil, err
+	}
+	if err := summoner.Setup(summonerDetail, battleTeam.Summoner.Level); err != nil {
+		return nil, err
+	}
 
 	monsterList := make([]*MonsterCard, 0)
 	for _, m := range battleTeam.Monsters {
-		mDetail := cardDetailMap[m.CardDetailID]
+		mDetail, err := getCardDetail(m)
+		if err != nil {
+			return nil, err
+		}
 		monster := MonsterCard{}
-		monster.Setup(mDetail, m.Level)
+		if err := monster.Setup(mDetail, m.Level); err != nil {
+			return nil, err
+		}
 		monsterList = append(monsterList, &monster)
 	}
 	var team GameTeam
 	team.Create(&summoner, monsterList, battleTeam.Player)
-	return &team
+	return &team, nil
 }
 
-func CreateGame(cardDetailMap CardDetailMap, battleDetails BattleDetails, rulesets []Ruleset, shouldLog bool) Game {
-	gameTeam1 := CreateGameTeam(cardDetailMap, battleDetails.Team1)
-	gameTeam2 := CreateGameTeam(cardDetailMap, battleDetails.Team2)
+func createGameTeams(cardDetailMap CardDetailMap, battleDetails BattleDetails) (*GameTeam, *GameTeam, error) {
+	gameTeam1, err := CreateGameTeam(cardDetailMap, battleDetails.Team1)
+	if err != nil {
+		return nil, nil, err
+	}
+	gameTeam2, err := CreateGameTeam(cardDetailMap, battleDetails.Team2)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gameTeam1, gameTeam2, nil
+}
+
+/* Creates the game of the battle. Returns a *CardDecodeError if a card of either team is unknown or can't be set up */
+func CreateGame(cardDetailMap CardDetailMap, battleDetails BattleDetails, rulesets []Ruleset, shouldLog bool) (Game, error) {
 	var game Game
+	gameTeam1, gameTeam2, err := createGameTeams(cardDetailMap, battleDetails)
+	if err != nil {
+		return game, err
+	}
 	game.Create(gameTeam1, gameTeam2, rulesets, shouldLog)
-	return game
+	return game, nil
+}
+
+/* Same as CreateGame but the game uses the balance parameters of the config */
+func CreateGameWithConfig(cardDetailMap CardDetailMap, battleDetails BattleDetails, rulesets []Ruleset, shouldLog bool, config EngineConfig) (Game, error) {
+	var game Game
+	gameTeam1, gameTeam2, err := createGameTeams(cardDetailMap, battleDetails)
+	if err != nil {
+		return game, err
+	}
+	game.CreateWithConfig(gameTeam1, gameTeam2, rulesets, shouldLog, config)
+	return game, nil
+}
+
+/* Same as CreateGame but the game is played with the rules of the version */
+func CreateGameWithRulesVersion(cardDetailMap CardDetailMap, battleDetails BattleDetails, rulesets []Ruleset, shouldLog bool, version RulesVersion) (Game, error) {
+	var game Game
+	gameTeam1, gameTeam2, err := createGameTeams(cardDetailMap, battleDetails)
+	if err != nil {
+		return game, err
+	}
+	game.CreateWithRulesVersion(gameTeam1, gameTeam2, rulesets, shouldLog, version)
+	return game, nil
+}
+
+/*
+Returns a factory of games created by createGame. The first game is created right away
+so that cards that can't be set up are reported once, instead of in every game.
+*/
+func newGameFactory(createGame func() (Game, error)) (func() *Game, error) {
+	if _, err := createGame(); err != nil {
+		return nil, err
+	}
+	return func() *Game {
+		// the same cards were set up without errors above
+		game, _ := createGame()
+		return &game
+	}, nil
 }
 
 func PrintStruct(value any) {
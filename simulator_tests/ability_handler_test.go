@@ -0,0 +1,166 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_ABILITY_FURY Ability = "Test Fury"
+
+/* +1 melee, and counts the hooks it was called for */
+type testFuryHandler struct {
+	BaseAbilityHandler
+	calls map[string]int
+}
+
+func (h testFuryHandler) GetAbility() Ability {
+	return TEST_ABILITY_FURY
+}
+
+func (h testFuryHandler) OnPreGame(g *Game, owner *MonsterCard) {
+	h.calls["pre game"] += 1
+}
+
+func (h testFuryHandler) OnHit(g *Game, owner *MonsterCard, hit *AbilityHitContext) {
+	h.calls["hit"] += 1
+}
+
+func (h testFuryHandler) OnBeingHit(g *Game, owner *MonsterCard, hit *AbilityHitContext) BattleDamage {
+	h.calls["being hit"] += 1
+	return BattleDamage{}
+}
+
+func (h testFuryHandler) ModifyStat(owner *MonsterCard, stat Stat, value int) int {
+	if stat == STAT_ATTACK {
+		return value + 1
+	}
+	return value
+}
+
+func TestAbilityHandlerRegistry(t *testing.T) {
+	t.Cleanup(ResetAbilityHandlers)
+	calls := make(map[string]int)
+	RegisterAbilityHandler(testFuryHandler{calls: calls})
+
+	// built in abilities are registered
+	assert.True(t, HasAbilityHandler(ABILITY_THORNS))
+	assert.True(t, HasAbilityHandler(ABILITY_REDEMPTION))
+	assert.True(t, HasAbilityHandler(TEST_ABILITY_FURY))
+	assert.False(t, HasAbilityHandler(Ability("Not an ability")))
+
+	// stat modifiers
+	m := GetDefaultFakeMonster(ATTACK_TYPE_MELEE)
+	assert.Equal(t, TEST_DEFAULT_ATTACK, m.GetPostAbilityMelee())
+	m.AddAbility(TEST_ABILITY_FURY)
+	assert.Equal(t, TEST_DEFAULT_ATTACK+1, m.GetPostAbilityMelee())
+	assert.Equal(t, TEST_DEFAULT_SPEED, m.GetPostAbilitySpeed())
+
+	// hooks of the attacker and the target
+	game, t1, t2 := CreateFakeGameAndTeams()
+	attacker := t1.GetFirstAliveMonster()
+	target := t2.GetFirstAliveMonster()
+	attacker.AddAbility(TEST_ABILITY_FURY)
+	target.AddAbility(TEST_ABILITY_FURY)
+	target.Armor = TEST_DEFAULT_ATTACK + 1
+	game.AttackMonsterPhase(attacker, target, ATTACK_TYPE_MELEE)
+	assert.Equal(t, 1, calls["hit"])
+	assert.Equal(t, 1, calls["being hit"])
+	assert.Equal(t, 0, target.Armor)
+
+	// replacing a handler keeps a single handler per ability
+	replaced := make(map[string]int)
+	RegisterAbilityHandler(testFuryHandler{calls: replaced})
+	game, t1, t2 = CreateFakeGameAndTeams()
+	t1.GetFirstAliveMonster().AddAbility(TEST_ABILITY_FURY)
+	game.AttackMonsterPhase(t1.GetFirstAliveMonster(), t2.GetFirstAliveMonster(), ATTACK_TYPE_MELEE)
+	assert.Equal(t, 1, replaced["hit"])
+	assert.Equal(t, 1, calls["hit"])
+}
+
+func TestUnregisterAbilityHandler(t *testing.T) {
+	t.Cleanup(ResetAbilityHandlers)
+	RegisterAbilityHandler(testFuryHandler{calls: make(map[string]int)})
+
+	assert.Nil(t, UnregisterAbilityHandler(TEST_ABILITY_FURY))
+	assert.False(t, HasAbilityHandler(TEST_ABILITY_FURY))
+	assert.NotNil(t, UnregisterAbilityHandler(TEST_ABILITY_FURY))
+
+	// built in handlers can be removed and come back with a reset
+	assert.Nil(t, UnregisterAbilityHandler(ABILITY_THORNS))
+	assert.False(t, HasAbilityHandler(ABILITY_THORNS))
+	assert.True(t, HasAbilityHandler(ABILITY_MAGIC_REFLECT))
+	ResetAbilityHandlers()
+	assert.True(t, HasAbilityHandler(ABILITY_THORNS))
+	assert.False(t, HasAbilityHandler(TEST_ABILITY_FURY))
+}
+
+func TestDivineShieldHandler(t *testing.T) {
+	t.Cleanup(ResetAbilityHandlers)
+
+	// the shield absorbs the attack and is used up
+	game, t1, t2 := CreateFakeGameAndTeams()
+	target := t2.GetFirstAliveMonster()
+	target.AddAbility(ABILITY_DIVINE_SHIELD)
+	game.AttackMonsterPhase(t1.GetFirstAliveMonster(), target, ATTACK_TYPE_MELEE)
+	assert.Equal(t, TEST_DEFAULT_ARMOR, target.Armor)
+	assert.False(t, target.HasAbility(ABILITY_DIVINE_SHIELD))
+
+	// side effect damage is absorbed too
+	target.AddAbility(ABILITY_DIVINE_SHIELD)
+	battleDamage := HitMonsterWithPhysical(game, target, TEST_DEFAULT_ARMOR)
+	assert.Equal(t, 0, battleDamage.DamageDone)
+	assert.Equal(t, TEST_DEFAULT_ARMOR, target.Armor)
+	assert.False(t, target.HasAbility(ABILITY_DIVINE_SHIELD))
+
+	// without the handler the ability does nothing
+	assert.Nil(t, UnregisterAbilityHandler(ABILITY_DIVINE_SHIELD))
+	game, t1, t2 = CreateFakeGameAndTeams()
+	target = t2.GetFirstAliveMonster()
+	target.AddAbility(ABILITY_DIVINE_SHIELD)
+	game.AttackMonsterPhase(t1.GetFirstAliveMonster(), target, ATTACK_TYPE_MELEE)
+	assert.Equal(t, 0, target.Armor)
+	assert.True(t, target.HasAbility(ABILITY_DIVINE_SHIELD))
+}
+
+func TestBlastHandler(t *testing.T) {
+	t.Cleanup(ResetAbilityHandlers)
+	setUp := func() (*Game, *MonsterCard, *MonsterCard, *MonsterCard) {
+		game, t1, t2 := CreateFakeGameAndTeams()
+		attacker := t1.GetFirstAliveMonster()
+		attacker.AddAbility(ABILITY_BLAST)
+		return game, attacker, t2.GetMonstersList()[0], t2.GetMonstersList()[1]
+	}
+
+	// the monster next to the target is blasted
+	game, attacker, target, next := setUp()
+	game.AttackMonsterPhase(attacker, target, ATTACK_TYPE_MELEE)
+	assert.Equal(t, 0, target.Armor)
+	assert.Less(t, next.Armor, TEST_DEFAULT_ARMOR)
+
+	// an absorbed attack still blasts, unless the rules say otherwise
+	game, attacker, target, next = setUp()
+	target.AddAbility(ABILITY_DIVINE_SHIELD)
+	game.AttackMonsterPhase(attacker, target, ATTACK_TYPE_MELEE)
+	assert.Equal(t, TEST_DEFAULT_ARMOR, target.Armor)
+	assert.Less(t, next.Armor, TEST_DEFAULT_ARMOR)
+
+	// without the handler the ability does nothing
+	assert.Nil(t, UnregisterAbilityHandler(ABILITY_BLAST))
+	game, attacker, target, next = setUp()
+	game.AttackMonsterPhase(attacker, target, ATTACK_TYPE_MELEE)
+	assert.Equal(t, 0, target.Armor)
+	assert.Equal(t, TEST_DEFAULT_ARMOR, next.Armor)
+}
+
+func TestRedemptionHandler(t *testing.T) {
+	game, t1, t2 := CreateFakeGameAndTeams()
+	dying := t1.GetFirstAliveMonster()
+	dying.AddAbility(ABILITY_REDEMPTION)
+	dying.Health = 0
+	game.ProcessIfDead(dying)
+	for _, e := range t2.GetAliveMonsters() {
+		assert.Equal(t, TEST_DEFAULT_ARMOR-REDEMPTION_DAMAGE, e.Armor)
+	}
+}
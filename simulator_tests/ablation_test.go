@@ -0,0 +1,43 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	simulator "github.com/YukiUmetsu/go-spl-simulator"
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeAblation(t *testing.T) {
+	// the striker is faster and would kill the shielded monster first, but Divine Shield absorbs its hit
+	shielded := createFakeMeleeCardDetail("Shielded", 5, 0, 5, 5)
+	shielded.ID = 2
+	shielded.Stats.Abilities = []any{[]any{string(ABILITY_DIVINE_SHIELD)}}
+	striker := createFakeMeleeCardDetail("Striker", 5, 0, 6, 5)
+	striker.ID = 3
+	summoner := GetDefaultFakeSummoner().GetCardDetail()
+	summoner.ID = 1
+	cardDetailMap := CardDetailMap{1: summoner, 2: shielded, 3: striker}
+	battleDetails := BattleDetails{
+		Team1: BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 1}, Monsters: []CollectionCard{{CardDetailID: 2, Level: 1}}},
+		Team2: BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 1}, Monsters: []CollectionCard{{CardDetailID: 3, Level: 1}}},
+	}
+
+	report, err := simulator.AnalyzeAblation(cardDetailMap, battleDetails, []Ruleset{}, TEAM_NUM_ONE, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, TEAM_NUM_ONE, report.Team)
+	assert.Equal(t, 10, report.Baseline.Wins)
+	assert.Equal(t, 1, len(report.Results))
+
+	result := report.Results[0]
+	assert.Equal(t, TEAM_NUM_ONE, result.Team)
+	assert.Equal(t, 0, result.Position)
+	assert.Equal(t, "Shielded", result.CardName)
+	assert.Equal(t, Ability(ABILITY_DIVINE_SHIELD), result.Ability)
+	assert.Equal(t, 0, result.Winrate.Wins)
+	assert.Equal(t, -1.0, result.Delta)
+	assert.LessOrEqual(t, result.DeltaLower, result.Delta)
+	assert.GreaterOrEqual(t, result.DeltaUpper, result.Delta)
+	assert.Less(t, result.DeltaUpper, 0.0)
+	assert.True(t, result.IsSignificant())
+}
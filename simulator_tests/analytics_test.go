@@ -0,0 +1,63 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	simulator "github.com/YukiUmetsu/go-spl-simulator"
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunBattleAnalytics(t *testing.T) {
+	// the striker always wins. the victim dies in round 1 in even games and survives one hit in odd games.
+	striker := []CardDetail{createFakeMeleeCardDetail("Striker", 5, 0, 6, 10)}
+	createShortGame := createFakeGameFactory(striker, []CardDetail{createFakeMeleeCardDetail("Victim", 5, 0, 1, 1)})
+	createLongGame := createFakeGameFactory(striker, []CardDetail{createFakeMeleeCardDetail("Victim", 15, 0, 1, 1)})
+	gameCount := 0
+	createGame := func() *Game {
+		gameCount += 1
+		if gameCount%2 == 0 {
+			return createShortGame()
+		}
+		return createLongGame()
+	}
+
+	analytics := simulator.RunBattleAnalytics(createGame, 4)
+	assert.Equal(t, 4, gameCount)
+	assert.Equal(t, 4, analytics.Games)
+	assert.Equal(t, 1.0, analytics.Team1WinRate)
+	assert.Equal(t, 0.0, analytics.Team2WinRate)
+	assert.Equal(t, 0.0, analytics.DrawRate)
+	assert.Equal(t, 2, len(analytics.Monsters))
+	assert.Equal(t, 2, len(analytics.Summoners))
+	assert.Equal(t, TEAM_NUM_ONE, analytics.Summoners[0].Team)
+	assert.Equal(t, TEAM_NUM_TWO, analytics.Summoners[1].Team)
+
+	strikerAnalytics := analytics.Monsters[0]
+	assert.Equal(t, TEAM_NUM_ONE, strikerAnalytics.Team)
+	assert.Equal(t, "Striker", strikerAnalytics.Name)
+	assert.Equal(t, 10.0, strikerAnalytics.AverageDamageDealt[ATTACK_TYPE_MELEE])
+	assert.Equal(t, 0.5, strikerAnalytics.AverageHealthDamageTaken)
+	assert.Equal(t, 1.0, strikerAnalytics.AverageKills)
+	assert.Equal(t, 1.5, strikerAnalytics.AverageTurnsTaken)
+	assert.Equal(t, 1.0, strikerAnalytics.SurvivalRate)
+	assert.Equal(t, map[int]int{5: 2, 15: 2}, strikerAnalytics.TotalDamageDistribution)
+	assert.Equal(t, map[int]int{1: 4}, strikerAnalytics.KillsDistribution)
+	assert.Equal(t, map[int]int{0: 4}, strikerAnalytics.DeathRoundDistribution)
+
+	victimAnalytics := analytics.Monsters[1]
+	assert.Equal(t, TEAM_NUM_TWO, victimAnalytics.Team)
+	assert.Equal(t, "Victim", victimAnalytics.Name)
+	assert.Equal(t, 0.5, victimAnalytics.AverageDamageDealt[ATTACK_TYPE_MELEE])
+	assert.Equal(t, 10.0, victimAnalytics.AverageHealthDamageTaken)
+	assert.Equal(t, 0.5, victimAnalytics.AverageTurnsTaken)
+	assert.Equal(t, 0.0, victimAnalytics.SurvivalRate)
+	assert.Equal(t, map[int]int{0: 2, 1: 2}, victimAnalytics.TotalDamageDistribution)
+	assert.Equal(t, map[int]int{1: 2, 2: 2}, victimAnalytics.DeathRoundDistribution)
+
+	// no games gives empty analytics
+	analytics = simulator.RunBattleAnalytics(createGame, 0)
+	assert.Equal(t, 0, analytics.Games)
+	assert.Empty(t, analytics.Monsters)
+	assert.Equal(t, 0.0, analytics.Team1WinRate)
+}
@@ -0,0 +1,80 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBattleStatsCollector(t *testing.T) {
+	var game *Game
+	var t1 *GameTeam
+	var t2 *GameTeam
+	var collector *BattleStatsCollector
+	var attacker *MonsterCard
+	var target *MonsterCard
+
+	setUp := func() {
+		game, t1, t2 = CreateFakeGameAndTeams()
+		collector = NewBattleStatsCollector()
+		game.AddEventListener(collector)
+		collector.AddGameCards(game)
+		attacker = t1.GetFirstAliveMonster()
+		target = t2.GetFirstAliveMonster()
+	}
+	getStats := func(m *MonsterCard) MonsterBattleStats {
+		for _, stats := range collector.GetMonsterStats() {
+			if stats.Team == m.GetTeamNumber() && stats.Position == m.GetCardPosition() {
+				return stats
+			}
+		}
+		return MonsterBattleStats{}
+	}
+
+	// reports every monster and summoner even if they did nothing
+	setUp()
+	assert.Equal(t, 6, len(collector.GetMonsterStats()))
+	assert.Equal(t, 2, len(collector.GetSummonerStats()))
+	assert.Equal(t, 0, getStats(attacker).GetTotalDamageDealt())
+
+	// records damage dealt per attack type and damage taken
+	setUp()
+	game.AttackMonsterPhase(attacker, target, ATTACK_TYPE_MELEE)
+	assert.Equal(t, TEST_DEFAULT_ATTACK, getStats(attacker).DamageDealt[ATTACK_TYPE_MELEE])
+	assert.Equal(t, TEST_DEFAULT_ARMOR, getStats(target).ArmorDamageTaken)
+	assert.Equal(t, 0, getStats(target).HealthDamageTaken)
+
+	// records kills and the round of death
+	setUp()
+	target.Armor = 0
+	game.AttackMonsterPhase(attacker, target, ATTACK_TYPE_MELEE)
+	assert.Equal(t, 1, getStats(attacker).Kills)
+	assert.Equal(t, 1, getStats(target).DeathRound)
+	assert.Equal(t, 0, getStats(attacker).DeathRound)
+
+	// ability damage is not counted as attack damage
+	setUp()
+	target.AddAbility(ABILITY_THORNS)
+	game.AttackMonsterPhase(attacker, target, ATTACK_TYPE_MELEE)
+	assert.Equal(t, TEST_DEFAULT_ATTACK, getStats(attacker).GetTotalDamageDealt())
+	assert.Equal(t, THORNS_DAMAGE, getStats(target).AbilityDamageDealt)
+
+	// records summoner repairs
+	setUp()
+	t1.GetSummoner().AddAbility(ABILITY_REPAIR)
+	attacker.Armor = 1
+	game.DoSummonerPreRound(t1)
+	summonerStats := collector.GetSummonerStats()[0]
+	assert.Equal(t, TEAM_NUM_ONE, summonerStats.Team)
+	assert.Equal(t, REPAIR_AMOUNT, summonerStats.RepairGiven)
+
+	// counts turns of every monster that took one
+	setUp()
+	game.PlayGame()
+	turns := 0
+	for _, stats := range collector.GetMonsterStats() {
+		turns += stats.TurnsTaken + stats.TurnsStunned
+	}
+	assert.Greater(t, turns, 0)
+}
@@ -0,0 +1,149 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	simulator "github.com/YukiUmetsu/go-spl-simulator"
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeCardStats(t *testing.T) {
+	// monsters have stats per level
+	monsterDetail := GetDefaultFakeMeleeOnlyCardDetail()
+	monsterDetail.Stats.Abilities = []any{[]any{}, []any{"Shield"}}
+	decoded, err := DecodeCardStats(monsterDetail)
+	assert.Nil(t, err)
+	assert.False(t, decoded.IsSummoner)
+	assert.Equal(t, 8, decoded.GetLevelCount())
+	assert.Equal(t, TEST_DEFAULT_ATTACK, decoded.MonsterStats.Attack[0])
+	assert.Equal(t, []Ability{}, decoded.GetAbilitiesOfLevel(1))
+	assert.Equal(t, []Ability{Ability(ABILITY_SHIELD)}, decoded.GetAbilitiesOfLevel(2))
+
+	// summoners have flat stats, json numbers are float64
+	summonerDetail := GetDefaultFakeSummoner().GetCardDetail()
+	summonerDetail.Stats.Attack = float64(1)
+	summonerDetail.Stats.Abilities = []any{"Void"}
+	decoded, err = DecodeCardStats(summonerDetail)
+	assert.Nil(t, err)
+	assert.True(t, decoded.IsSummoner)
+	assert.Equal(t, 1, decoded.SummonerStats.Attack)
+	assert.Equal(t, []Ability{Ability(ABILITY_VOID)}, decoded.GetAbilitiesOfLevel(1))
+
+	// more levels than the max level of the rarity
+	tooManyLevels := GetDefaultFakeMeleeOnlyCardDetail()
+	tooManyLevels.Rarity = 4
+	_, err = DecodeCardStats(tooManyLevels)
+	decodeErr, ok := err.(*CardDecodeError)
+	assert.True(t, ok)
+	assert.Equal(t, tooManyLevels.ID, decodeErr.CardDetailID)
+	assert.Equal(t, "health", decodeErr.Field)
+
+	// stat arrays of different lengths
+	mismatched := GetDefaultFakeMeleeOnlyCardDetail()
+	mismatched.Stats.Speed = []any{1, 2}
+	_, err = DecodeCardStats(mismatched)
+	decodeErr, ok = err.(*CardDecodeError)
+	assert.True(t, ok)
+	assert.Equal(t, "speed", decodeErr.Field)
+
+	// unexpected shapes
+	wrongShape := GetDefaultFakeMeleeOnlyCardDetail()
+	wrongShape.Stats.Mana = float64(3)
+	_, err = DecodeCardStats(wrongShape)
+	assert.NotNil(t, err)
+
+	wrongAbilities := GetDefaultFakeMeleeOnlyCardDetail()
+	wrongAbilities.Stats.Abilities = []any{"Shield"}
+	_, err = DecodeCardStats(wrongAbilities)
+	assert.NotNil(t, err)
+
+	missingStat := GetDefaultFakeSummoner().GetCardDetail()
+	missingStat.Stats.Speed = nil
+	_, err = DecodeCardStats(missingStat)
+	assert.NotNil(t, err)
+
+	// DecodeCardDetails leaves out malformed cards
+	cardDetailMap, errs := DecodeCardDetails([]CardDetail{monsterDetail, mismatched})
+	assert.Equal(t, 1, len(cardDetailMap))
+	assert.Equal(t, 1, len(errs))
+}
+
+func TestSetupMalformedCard(t *testing.T) {
+	// doesn't panic and returns the decode error
+	malformed := GetDefaultFakeMeleeOnlyCardDetail()
+	malformed.Stats.Health.([]any)[0] = "a lot"
+	var m MonsterCard
+	err := (&m).Setup(malformed, 1)
+	assert.Equal(t, 0, m.Health)
+	decodeErr, ok := err.(*CardDecodeError)
+	assert.True(t, ok)
+	assert.Equal(t, "health", decodeErr.Field)
+
+	// level is kept in the levels of the card
+	var m2 MonsterCard
+	err = (&m2).Setup(GetDefaultFakeMeleeOnlyCardDetail(), 20)
+	assert.Equal(t, 8, m2.GetCardLevel())
+	assert.Equal(t, TEST_DEFAULT_HEALTH, m2.Health)
+	decodeErr, ok = err.(*CardDecodeError)
+	assert.True(t, ok)
+	assert.Equal(t, "level", decodeErr.Field)
+
+	// a monster can't be set up as a summoner and the other way around
+	var s SummonerCard
+	err = (&s).Setup(GetDefaultFakeMeleeOnlyCardDetail(), 1)
+	assert.NotNil(t, err)
+	var m3 MonsterCard
+	err = (&m3).Setup(GetDefaultFakeSummoner().GetCardDetail(), 1)
+	assert.NotNil(t, err)
+
+	// valid cards have no error
+	assert.Nil(t, (&MonsterCard{}).Setup(GetDefaultFakeMeleeOnlyCardDetail(), 1))
+	assert.Nil(t, (&SummonerCard{}).Setup(GetDefaultFakeSummoner().GetCardDetail(), 1))
+}
+
+func TestCreateGame(t *testing.T) {
+	summoner := GetDefaultFakeSummoner().GetCardDetail()
+	summoner.ID = 1
+	monster := GetDefaultFakeMeleeOnlyCardDetail()
+	monster.ID = 2
+	malformed := GetDefaultFakeMeleeOnlyCardDetail()
+	malformed.ID, malformed.Name = 3, "Malformed"
+	malformed.Stats.Health.([]any)[0] = "a lot"
+	cardDetailMap := CardDetailMap{1: summoner, 2: monster, 3: malformed}
+	createBattle := func(monsterID int) BattleDetails {
+		team := BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 1}, Monsters: []CollectionCard{{CardDetailID: 2, Level: 1}}}
+		opponent := BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 1}, Monsters: []CollectionCard{{CardDetailID: monsterID, Level: 1}}}
+		return BattleDetails{Team1: team, Team2: opponent}
+	}
+
+	cases := []struct {
+		name          string
+		monsterID     int
+		expectedField string // field of the decode error, empty for no error
+	}{
+		{"valid cards", 2, ""},
+		{"malformed card", 3, "health"},
+		{"unknown card", 99, "id"},
+	}
+	for _, c := range cases {
+		game, err := simulator.CreateGame(cardDetailMap, createBattle(c.monsterID), []Ruleset{}, false)
+		if c.expectedField == "" {
+			assert.Nil(t, err, c.name)
+			assert.Equal(t, 1, len(game.GetTeam(TEAM_NUM_TWO).GetMonstersList()), c.name)
+			continue
+		}
+		decodeErr, ok := err.(*CardDecodeError)
+		assert.True(t, ok, c.name)
+		assert.Equal(t, c.monsterID, decodeErr.CardDetailID, c.name)
+		assert.Equal(t, c.expectedField, decodeErr.Field, c.name)
+	}
+
+	// the error is passed up by the analyses
+	_, err := simulator.SimulateBattleAnalytics(cardDetailMap, createBattle(3), []Ruleset{}, 1)
+	assert.NotNil(t, err)
+	_, err = simulator.AnalyzeAblation(cardDetailMap, createBattle(99), []Ruleset{}, TEAM_NUM_ONE, 1)
+	assert.NotNil(t, err)
+	_, err = simulator.EstimateWinrateAgainstOpponents(cardDetailMap, createBattle(2).Team1, []BattleTeam{createBattle(3).Team2}, []Ruleset{}, 1)
+	assert.NotNil(t, err)
+}
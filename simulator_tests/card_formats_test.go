@@ -0,0 +1,71 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEditions(t *testing.T) {
+	editions, err := ParseEditions("7,8")
+	assert.Nil(t, err)
+	assert.Equal(t, []CardEdition{CHAOS, RIFTWATCHERS}, editions)
+
+	editions, err = ParseEditions(" 10 , 12")
+	assert.Nil(t, err)
+	assert.Equal(t, []CardEdition{SOULBOUND, REBELLION}, editions)
+
+	editions, err = ParseEditions("13,14")
+	assert.Nil(t, err)
+	assert.Equal(t, []CardEdition{REBELLION_REWARD, CONCLAVE_ARCANA}, editions)
+
+	editions, err = ParseEditions("")
+	assert.Nil(t, err)
+	assert.Empty(t, editions)
+
+	_, err = ParseEditions("1,x")
+	assert.NotNil(t, err)
+}
+
+func TestIsCardLegal(t *testing.T) {
+	createCard := func(editions string, tier int) CardDetail {
+		cd := GetDefaultFakeMeleeOnlyCardDetail()
+		cd.Editions = editions
+		cd.Tier = tier
+		return cd
+	}
+
+	// every card is legal in wild
+	wild := NewWildFormat()
+	assert.True(t, wild.IsCardLegal(createCard("0,1", 0)))
+	assert.True(t, wild.IsCardLegal(createCard("12", 0)))
+	assert.True(t, wild.IsCardLegal(createCard("14", 0)))
+
+	// only newer sets and newer reward cards are legal in modern
+	modern := NewModernFormat()
+	assert.False(t, modern.IsCardLegal(createCard("0,1", 0)))
+	assert.False(t, modern.IsCardLegal(createCard("4", 0)))
+	assert.True(t, modern.IsCardLegal(createCard("7", 0)))
+	assert.True(t, modern.IsCardLegal(createCard("12", 0)))
+	assert.True(t, modern.IsCardLegal(createCard("13", 0)))
+	assert.True(t, modern.IsCardLegal(createCard("14", 0)))
+	assert.False(t, modern.IsCardLegal(createCard("3", 4)))
+	assert.True(t, modern.IsCardLegal(createCard("3", 7)))
+
+	// only the allowed editions are legal in a custom format
+	custom := NewCustomFormat("Untamed Brawl", BattleAllowedCards{Editions: []int{4}})
+	assert.True(t, custom.IsCardLegal(createCard("4", 0)))
+	assert.False(t, custom.IsCardLegal(createCard("7", 0)))
+}
+
+func TestValidateTeamForFormat(t *testing.T) {
+	// fake cards are beta cards
+	team := CreateFakeGameTeam()
+	assert.Empty(t, NewWildFormat().ValidateTeam(team))
+
+	errs := NewModernFormat().ValidateTeam(team)
+	assert.Equal(t, 4, len(errs))
+	_, ok := errs[0].(*FormatRestrictionError)
+	assert.True(t, ok)
+}
@@ -0,0 +1,142 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	simulator "github.com/YukiUmetsu/go-spl-simulator"
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCardLevelFromXP(t *testing.T) {
+	createCardDetail := func(rarity, tier int) CardDetail {
+		cardDetail := GetDefaultFakeMeleeOnlyCardDetail()
+		cardDetail.Rarity = rarity
+		cardDetail.Tier = tier
+		return cardDetail
+	}
+
+	cases := []struct {
+		name     string
+		rarity   int
+		tier     int
+		xp       int
+		edition  int
+		gold     bool
+		expected int
+	}{
+		// legacy cards use xp levels
+		{name: "legacy common without xp", rarity: 1, xp: 0, edition: 1, expected: 1},
+		{name: "legacy common at level 2 xp", rarity: 1, xp: 20, edition: 1, expected: 2},
+		{name: "legacy common below level 4 xp", rarity: 1, xp: 159, edition: 1, expected: 3},
+		{name: "legacy common at max xp", rarity: 1, xp: 7560, edition: 1, expected: 10},
+		{name: "legacy legendary above max xp", rarity: 4, xp: 99999, edition: 0, expected: 4},
+		// legacy gold cards have at least the xp of a single gold card
+		{name: "legacy gold common without xp", rarity: 1, xp: 0, edition: 1, gold: true, expected: 4},
+		{name: "legacy gold alpha common without xp", rarity: 1, xp: 0, edition: 0, gold: true, expected: 4},
+		{name: "legacy gold rare without xp", rarity: 2, xp: 0, edition: 2, gold: true, expected: 3},
+		{name: "legacy gold legendary without xp", rarity: 4, xp: 0, edition: 1, gold: true, expected: 2},
+		{name: "legacy gold alpha legendary of 2 cards", rarity: 4, xp: 5000, edition: 0, gold: true, expected: 3},
+		{name: "legacy gold common of 5 cards", rarity: 1, xp: 1000, edition: 1, gold: true, expected: 6},
+		// cards from untamed and later count combined cards
+		{name: "bcx common of 1 card", rarity: 1, xp: 1, edition: 4, expected: 1},
+		{name: "bcx common of 5 cards", rarity: 1, xp: 5, edition: 7, expected: 2},
+		{name: "bcx legendary of 6 cards", rarity: 4, xp: 6, edition: 7, expected: 3},
+		{name: "bcx common at max", rarity: 1, xp: 400, edition: 7, expected: 10},
+		// reward cards of a newer tier count combined cards too
+		{name: "reward card of a bcx tier", rarity: 1, tier: 7, xp: 5, edition: 3, expected: 2},
+		// gold cards use the gold combine rates
+		{name: "bcx gold legendary without xp", rarity: 4, xp: 0, edition: 7, gold: true, expected: 1},
+		{name: "bcx gold legendary of 1 card", rarity: 4, xp: 1, edition: 7, gold: true, expected: 2},
+		{name: "bcx gold common of 1 card", rarity: 1, xp: 1, edition: 7, gold: true, expected: 3},
+		// unknown rarity
+		{name: "unknown rarity", rarity: 0, xp: 1, edition: 7, expected: 0},
+		{name: "unknown rarity of a legacy gold card", rarity: 0, xp: 1, edition: 1, gold: true, expected: 0},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, GetCardLevelFromXP(createCardDetail(c.rarity, c.tier), c.xp, c.edition, c.gold), c.name)
+	}
+}
+
+func TestGetMonsterLevelCap(t *testing.T) {
+	// level 1 legendary summoner
+	assert.Equal(t, 3, GetMonsterLevelCap(4, 1, 1))
+	assert.Equal(t, 2, GetMonsterLevelCap(4, 1, 2))
+	assert.Equal(t, 2, GetMonsterLevelCap(4, 1, 3))
+	assert.Equal(t, 1, GetMonsterLevelCap(4, 1, 4))
+
+	// level 5 common summoner
+	assert.Equal(t, 5, GetMonsterLevelCap(1, 5, 1))
+	assert.Equal(t, 3, GetMonsterLevelCap(1, 5, 3))
+
+	// max level summoner doesn't cap
+	assert.Equal(t, 10, GetMonsterLevelCap(2, 8, 1))
+	assert.Equal(t, 4, GetMonsterLevelCap(2, 8, 4))
+}
+
+/* Legendary summoner 1, common monster 2 and legendary monster 3 */
+func createFakeCappedCatalog() CardDetailMap {
+	summoner := GetDefaultFakeSummoner().GetCardDetail()
+	summoner.ID, summoner.Name, summoner.Rarity = 1, "Legendary Summoner", 4
+	common := GetDefaultFakeMeleeOnlyCardDetail()
+	common.ID, common.Name, common.Rarity = 2, "Common Monster", 1
+	legendary := GetDefaultFakeMeleeOnlyCardDetail()
+	legendary.ID, legendary.Name, legendary.Rarity = 3, "Legendary Monster", 4
+	// legendaries have 4 levels
+	for _, stat := range []*any{&legendary.Stats.Mana, &legendary.Stats.Health, &legendary.Stats.Speed, &legendary.Stats.Armor, &legendary.Stats.Attack, &legendary.Stats.Ranged, &legendary.Stats.Magic} {
+		*stat = (*stat).([]any)[:4]
+	}
+	return CardDetailMap{1: summoner, 2: common, 3: legendary}
+}
+
+func TestApplySummonerLevelCap(t *testing.T) {
+	cardDetailMap := createFakeCappedCatalog()
+	createTeam := func(summonerLevel, commonLevel, legendaryLevel int) BattleTeam {
+		return BattleTeam{
+			Summoner: CollectionCard{CardDetailID: 1, Level: summonerLevel},
+			Monsters: []CollectionCard{{CardDetailID: 2, Level: commonLevel}, {CardDetailID: 3, Level: legendaryLevel}},
+		}
+	}
+
+	cases := []struct {
+		name           string
+		team           BattleTeam
+		expectedLevels []int
+		expected       []LevelCapAdjustment
+	}{
+		{"max level summoner doesn't cap", createTeam(4, 10, 4), []int{10, 4}, []LevelCapAdjustment{}},
+		{"monsters under the cap", createTeam(1, 3, 1), []int{3, 1}, []LevelCapAdjustment{}},
+		{"level 1 legendary summoner", createTeam(1, 8, 2), []int{3, 1}, []LevelCapAdjustment{
+			{Position: 0, CardDetailID: 2, CardName: "Common Monster", Level: 8, CappedLevel: 3},
+			{Position: 1, CardDetailID: 3, CardName: "Legendary Monster", Level: 2, CappedLevel: 1},
+		}},
+		{"level 2 legendary summoner", createTeam(2, 6, 2), []int{5, 2}, []LevelCapAdjustment{
+			{Position: 0, CardDetailID: 2, CardName: "Common Monster", Level: 6, CappedLevel: 5},
+		}},
+		{"level 0 counts as level 1", createTeam(0, 4, 1), []int{3, 1}, []LevelCapAdjustment{
+			{Position: 0, CardDetailID: 2, CardName: "Common Monster", Level: 4, CappedLevel: 3},
+		}},
+	}
+	for _, c := range cases {
+		original := c.team.Monsters[0].Level
+		capped, adjustments := ApplySummonerLevelCap(cardDetailMap, c.team)
+		assert.Equal(t, c.expected, adjustments, c.name)
+		assert.Equal(t, c.expectedLevels, []int{capped.Monsters[0].Level, capped.Monsters[1].Level}, c.name)
+		// the summoner and the team are not modified
+		assert.Equal(t, c.team.Summoner, capped.Summoner, c.name)
+		assert.Equal(t, original, c.team.Monsters[0].Level, c.name)
+	}
+}
+
+func TestCreateCappedGame(t *testing.T) {
+	cardDetailMap := createFakeCappedCatalog()
+	team1 := BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 1}, Monsters: []CollectionCard{{CardDetailID: 2, Level: 8}}}
+	team2 := BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 4}, Monsters: []CollectionCard{{CardDetailID: 2, Level: 8}}}
+
+	game, team1Adjustments, team2Adjustments, err := simulator.CreateCappedGame(cardDetailMap, BattleDetails{Team1: team1, Team2: team2}, []Ruleset{}, false)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(team1Adjustments))
+	assert.Empty(t, team2Adjustments)
+	assert.Equal(t, 3, game.GetTeam(TEAM_NUM_ONE).GetMonstersList()[0].CardLevel)
+	assert.Equal(t, 8, game.GetTeam(TEAM_NUM_TWO).GetMonstersList()[0].CardLevel)
+}
@@ -0,0 +1,141 @@
+package simulator_tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	simulator "github.com/YukiUmetsu/go-spl-simulator"
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardOverlayApply(t *testing.T) {
+	setUp := func() CardDetailMap {
+		monster := GetDefaultFakeMeleeOnlyCardDetail()
+		monster.ID = 262
+		monster.Stats.Abilities = []any{[]any{}, []any{"Shield"}, []any{}, []any{"Thorns"}}
+		summoner := GetDefaultFakeSummoner().GetCardDetail()
+		summoner.ID = 1
+		return CardDetailMap{monster.ID: monster, summoner.ID: summoner}
+	}
+
+	// +1 speed at all levels
+	cardDetailMap := setUp()
+	overlay := CardOverlay{Name: "speed buff", Cards: []CardStatOverride{{CardDetailID: 262, Add: map[string]int{"speed": 1}}}}
+	overlaid, err := overlay.Apply(cardDetailMap)
+	assert.Nil(t, err)
+	decoded, _ := DecodeCardStats(overlaid[262])
+	for _, speed := range decoded.MonsterStats.Speed {
+		assert.Equal(t, TEST_DEFAULT_SPEED+1, speed)
+	}
+	// the catalog is not modified
+	decoded, _ = DecodeCardStats(cardDetailMap[262])
+	assert.Equal(t, TEST_DEFAULT_SPEED, decoded.MonsterStats.Speed[0])
+
+	// remove thorns from level 4
+	overlay = CardOverlay{Name: "thorns nerf", Cards: []CardStatOverride{{CardDetailID: 262, Levels: []int{4}, RemoveAbilities: []Ability{ABILITY_THORNS}}}}
+	overlaid, err = overlay.Apply(cardDetailMap)
+	assert.Nil(t, err)
+	var m MonsterCard
+	m.Setup(overlaid[262], 4)
+	assert.False(t, m.HasAbility(ABILITY_THORNS))
+	assert.True(t, m.HasAbility(ABILITY_SHIELD))
+
+	// remove thorns from level 4 when it unlocks at level 1: it is unlocked again at level 5
+	cardDetailMap = setUp()
+	earlyThorns := cardDetailMap[262]
+	earlyThorns.Stats.Abilities = []any{[]any{"Thorns"}, []any{"Shield"}}
+	cardDetailMap[262] = earlyThorns
+	overlay = CardOverlay{Cards: []CardStatOverride{{CardDetailID: 262, Levels: []int{4}, RemoveAbilities: []Ability{ABILITY_THORNS}}}}
+	overlaid, err = overlay.Apply(cardDetailMap)
+	assert.Nil(t, err)
+	for level, expected := range map[int]bool{1: false, 4: false, 5: true, 8: true} {
+		m = MonsterCard{}
+		m.Setup(overlaid[262], level)
+		assert.Equal(t, expected, m.HasAbility(ABILITY_THORNS), "level %d", level)
+		assert.Equal(t, level >= 2, m.HasAbility(ABILITY_SHIELD), "level %d", level)
+	}
+
+	// removing an ability the card doesn't have at the levels
+	_, err = CardOverlay{Cards: []CardStatOverride{{CardDetailID: 262, Levels: []int{2}, RemoveAbilities: []Ability{ABILITY_FLYING}}}}.Apply(cardDetailMap)
+	assert.NotNil(t, err)
+	cardDetailMap = setUp()
+	_, err = CardOverlay{Cards: []CardStatOverride{{CardDetailID: 262, Levels: []int{3}, RemoveAbilities: []Ability{ABILITY_THORNS}}}}.Apply(cardDetailMap)
+	assert.NotNil(t, err)
+
+	// set health at level 1 and add an ability from level 1
+	overlay = CardOverlay{Cards: []CardStatOverride{{CardDetailID: 262, Levels: []int{1}, Set: map[string]int{"health": 1}, AddAbilities: []Ability{ABILITY_FLYING}}}}
+	overlaid, err = overlay.Apply(cardDetailMap)
+	assert.Nil(t, err)
+	m = MonsterCard{}
+	m.Setup(overlaid[262], 1)
+	assert.Equal(t, 1, m.Health)
+	assert.True(t, m.HasAbility(ABILITY_FLYING))
+
+	// summoners have flat stats
+	overlay = CardOverlay{Cards: []CardStatOverride{{CardDetailID: 1, Add: map[string]int{"armor": 1}}}}
+	overlaid, err = overlay.Apply(cardDetailMap)
+	assert.Nil(t, err)
+	decoded, _ = DecodeCardStats(overlaid[1])
+	assert.True(t, decoded.IsSummoner)
+	assert.Equal(t, 1, decoded.SummonerStats.Armor)
+
+	// unknown card, stat or level
+	_, err = CardOverlay{Cards: []CardStatOverride{{CardDetailID: 999}}}.Apply(cardDetailMap)
+	assert.NotNil(t, err)
+	_, err = CardOverlay{Cards: []CardStatOverride{{CardDetailID: 262, Add: map[string]int{"luck": 1}}}}.Apply(cardDetailMap)
+	assert.NotNil(t, err)
+	_, err = CardOverlay{Cards: []CardStatOverride{{CardDetailID: 262, Levels: []int{9}, Add: map[string]int{"speed": 1}}}}.Apply(cardDetailMap)
+	assert.NotNil(t, err)
+}
+
+func TestLoadCardOverlay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overlay.yaml")
+	content := "name: thorns nerf\ncards:\n  - card_detail_id: 262\n    levels: [4]\n    remove_abilities: [Thorns]\n    add:\n      speed: 1\n"
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0644))
+
+	overlay, err := LoadCardOverlay(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "thorns nerf", overlay.Name)
+	assert.Equal(t, 262, overlay.Cards[0].CardDetailID)
+	assert.Equal(t, []int{4}, overlay.Cards[0].Levels)
+	assert.Equal(t, []Ability{ABILITY_THORNS}, overlay.Cards[0].RemoveAbilities)
+	assert.Equal(t, 1, overlay.Cards[0].Add["speed"])
+}
+
+func TestCompareCardOverlay(t *testing.T) {
+	summoner := GetDefaultFakeSummoner().GetCardDetail()
+	summoner.ID = 1
+	// the striker hits first but dies before the wall does, unless its attack is buffed
+	striker := createFakeMeleeCardDetail("Striker", 5, 0, 6, 2)
+	striker.ID = 2
+	wall := createFakeMeleeCardDetail("Wall", 10, 0, 5, 3)
+	wall.ID = 3
+	cardDetailMap := CardDetailMap{1: summoner, 2: striker, 3: wall}
+	team := BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 1}, Monsters: []CollectionCard{{CardDetailID: 2, Level: 1}}}
+	opponent := BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 1}, Monsters: []CollectionCard{{CardDetailID: 3, Level: 1}}}
+
+	// the baseline uses the catalog, only the modified side uses the overlay
+	overlay := CardOverlay{Name: "striker buff", Cards: []CardStatOverride{{CardDetailID: 2, Add: map[string]int{"attack": 8}}}}
+	comparison, err := simulator.CompareCardOverlay(cardDetailMap, overlay, team, []BattleTeam{opponent}, []Ruleset{}, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, "striker buff", comparison.Overlay)
+	assert.Equal(t, 0, comparison.Baseline.Wins)
+	assert.Equal(t, 4, comparison.Modified.Wins)
+	assert.Equal(t, 1.0, comparison.Delta)
+	assert.True(t, comparison.IsSignificant())
+
+	// the catalog is not modified
+	decoded, _ := DecodeCardStats(cardDetailMap[2])
+	for _, attack := range decoded.MonsterStats.Attack {
+		assert.Equal(t, 2, attack)
+	}
+	baseline, err := simulator.EstimateWinrateAgainstOpponents(cardDetailMap, team, []BattleTeam{opponent}, []Ruleset{}, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, baseline.Wins)
+
+	// an overlay that can't be applied
+	_, err = simulator.CompareCardOverlay(cardDetailMap, CardOverlay{Cards: []CardStatOverride{{CardDetailID: 999}}}, team, []BattleTeam{opponent}, []Ruleset{}, 4)
+	assert.NotNil(t, err)
+}
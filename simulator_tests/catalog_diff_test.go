@@ -0,0 +1,96 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffCardCatalogs(t *testing.T) {
+	setUp := func() (CardDetailMap, CardDetailMap) {
+		monster := GetDefaultFakeMeleeOnlyCardDetail()
+		monster.ID = 1
+		monster.Stats.Abilities = []any{[]any{"Shield"}, []any{}, []any{"Thorns"}}
+		summoner := GetDefaultFakeSummoner().GetCardDetail()
+		summoner.ID = 2
+		before := CardDetailMap{monster.ID: monster, summoner.ID: summoner}
+		after := CardDetailMap{monster.ID: monster, summoner.ID: summoner}
+		return before, after
+	}
+
+	// same catalog
+	before, after := setUp()
+	diffs, errs := DiffCardCatalogs(before, after)
+	assert.Empty(t, diffs)
+	assert.Empty(t, errs)
+
+	// stat, mana and ability changes
+	before, after = setUp()
+	overlay := CardOverlay{Cards: []CardStatOverride{
+		{CardDetailID: 1, Levels: []int{2}, Add: map[string]int{"mana": 1, "speed": -1}},
+		{CardDetailID: 1, Levels: []int{3}, RemoveAbilities: []Ability{ABILITY_THORNS}, AddAbilities: []Ability{ABILITY_RETALIATE}},
+		{CardDetailID: 2, Add: map[string]int{"armor": 1}},
+	}}
+	after, err := overlay.Apply(after)
+	assert.Nil(t, err)
+	diffs, errs = DiffCardCatalogs(before, after)
+	assert.Empty(t, errs)
+	assert.Equal(t, 2, len(diffs))
+
+	monsterDiff := diffs[0]
+	assert.Equal(t, 1, monsterDiff.CardDetailID)
+	assert.True(t, monsterDiff.HasManaChange())
+	assert.Equal(t, []CardStatChange{
+		{Stat: "mana", Level: 2, Before: TEST_DEFAULT_MANA, After: TEST_DEFAULT_MANA + 1},
+		{Stat: "speed", Level: 2, Before: TEST_DEFAULT_SPEED, After: TEST_DEFAULT_SPEED - 1},
+	}, monsterDiff.StatChanges)
+	assert.Equal(t, []CardAbilityChange{
+		{Level: 3, Ability: ABILITY_THORNS, Added: false},
+		{Level: 3, Ability: ABILITY_RETALIATE, Added: true},
+		// thorns is kept above the levels it was removed from
+		{Level: 4, Ability: ABILITY_THORNS, Added: true},
+	}, monsterDiff.AbilityChanges)
+
+	summonerDiff := diffs[1]
+	assert.False(t, summonerDiff.HasManaChange())
+	assert.Equal(t, []CardStatChange{{Stat: "armor", Level: 0, Before: 0, After: 1}}, summonerDiff.StatChanges)
+
+	// added and removed cards
+	before, after = setUp()
+	delete(after, 2)
+	added := GetDefaultFakeMeleeOnlyCardDetail()
+	added.ID = 3
+	after[3] = added
+	diffs, errs = DiffCardCatalogs(before, after)
+	assert.Empty(t, errs)
+	assert.Equal(t, 2, len(diffs))
+	assert.True(t, diffs[0].IsRemoved)
+	assert.True(t, diffs[1].IsAdded)
+
+	// a card that can't be decoded is reported instead of showing no changes
+	before, after = setUp()
+	malformed := after[1]
+	malformed.Stats.Speed = []any{1, 2}
+	after[1] = malformed
+	diffs, errs = DiffCardCatalogs(before, after)
+	assert.Empty(t, diffs)
+	assert.Equal(t, 1, len(errs))
+	decodeErr, ok := errs[0].(*CardDecodeError)
+	assert.True(t, ok)
+	assert.Equal(t, 1, decodeErr.CardDetailID)
+}
+
+func TestDiffCards(t *testing.T) {
+	monster := GetDefaultFakeMeleeOnlyCardDetail()
+	monster.ID = 1
+	malformed := monster
+	malformed.Stats.Speed = []any{1, 2}
+
+	_, err := DiffCards(monster, monster)
+	assert.Nil(t, err)
+	_, err = DiffCards(malformed, monster)
+	assert.NotNil(t, err)
+	_, err = DiffCards(monster, malformed)
+	assert.NotNil(t, err)
+}
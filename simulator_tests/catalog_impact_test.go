@@ -0,0 +1,26 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	simulator "github.com/YukiUmetsu/go-spl-simulator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsWinrateFlipped(t *testing.T) {
+	cases := []struct {
+		name          string
+		before, after simulator.WinrateEstimate
+		expected      bool
+	}{
+		{"surely flipped", simulator.NewWinrateEstimate(80, 100), simulator.NewWinrateEstimate(20, 100), true},
+		{"surely flipped back", simulator.NewWinrateEstimate(20, 100), simulator.NewWinrateEstimate(80, 100), true},
+		// the point estimates cross 0.5 but the bounds overlap it
+		{"within the noise", simulator.NewWinrateEstimate(52, 100), simulator.NewWinrateEstimate(48, 100), false},
+		{"only one side is sure", simulator.NewWinrateEstimate(80, 100), simulator.NewWinrateEstimate(45, 100), false},
+		{"same side", simulator.NewWinrateEstimate(80, 100), simulator.NewWinrateEstimate(60, 100), false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, simulator.IsWinrateFlipped(c.before, c.after), c.name)
+	}
+}
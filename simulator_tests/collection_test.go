@@ -0,0 +1,92 @@
+package simulator_tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	simulator "github.com/YukiUmetsu/go-spl-simulator"
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_COLLECTION_JSON = `{
+	"player": "test_player",
+	"cards": [
+		{"uid": "C7-2-A", "card_detail_id": 2, "xp": 5, "edition": 7, "gold": false},
+		{"uid": "C7-2-B", "card_detail_id": 2, "xp": 30, "edition": 7, "gold": false},
+		{"uid": "C7-2-C", "card_detail_id": 2, "xp": 1, "edition": 7, "gold": false},
+		{"uid": "G7-3-A", "card_detail_id": 3, "xp": 1, "edition": 7, "gold": true},
+		{"uid": "G1-1-A", "card_detail_id": 1, "xp": 0, "edition": 1, "gold": true},
+		{"uid": "C7-99-A", "card_detail_id": 99, "xp": 1, "edition": 7, "gold": false}
+	]
+}`
+
+func TestLoadPlayerCollection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "collection.json")
+	assert.Nil(t, os.WriteFile(path, []byte(TEST_COLLECTION_JSON), 0644))
+
+	collection, err := simulator.LoadPlayerCollection(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "test_player", collection.Player)
+	assert.Equal(t, 6, len(collection.Cards))
+	assert.Equal(t, CollectionCard{UID: "G7-3-A", CardDetailID: 3, XP: 1, Edition: 7, Gold: true}, collection.Cards[3])
+
+	// missing file
+	_, err = simulator.LoadPlayerCollection(filepath.Join(t.TempDir(), "missing.json"))
+	assert.NotNil(t, err)
+
+	// invalid json
+	assert.Nil(t, os.WriteFile(path, []byte("{"), 0644))
+	_, err = simulator.LoadPlayerCollection(path)
+	assert.NotNil(t, err)
+}
+
+func TestGetCardPool(t *testing.T) {
+	cardDetailMap := createFakeCappedCatalog()
+	cases := []struct {
+		name     string
+		cards    []CollectionCard
+		expected map[int]int // card detail id: level
+	}{
+		{"empty collection", []CollectionCard{}, map[int]int{}},
+		{"keeps the highest level copy", []CollectionCard{
+			{UID: "a", CardDetailID: 2, XP: 5, Edition: 7},
+			{UID: "b", CardDetailID: 2, XP: 30, Edition: 7},
+			{UID: "c", CardDetailID: 2, XP: 1, Edition: 7},
+		}, map[int]int{2: 4}},
+		{"bcx gold cards use the gold combine rates", []CollectionCard{
+			{UID: "a", CardDetailID: 3, XP: 1, Edition: 7, Gold: true},
+		}, map[int]int{3: 2}},
+		{"a gold copy beats a regular copy of the same xp", []CollectionCard{
+			{UID: "a", CardDetailID: 2, XP: 1, Edition: 7},
+			{UID: "b", CardDetailID: 2, XP: 1, Edition: 7, Gold: true},
+		}, map[int]int{2: 3}},
+		{"legacy gold cards start above level 1", []CollectionCard{
+			{UID: "a", CardDetailID: 1, XP: 0, Edition: 1, Gold: true},
+		}, map[int]int{1: 2}},
+		{"unknown cards are skipped", []CollectionCard{
+			{UID: "a", CardDetailID: 99, XP: 1, Edition: 7},
+		}, map[int]int{}},
+	}
+	for _, c := range cases {
+		pool := simulator.GetCardPool(cardDetailMap, simulator.PlayerCollection{Cards: c.cards})
+		levels := make(map[int]int)
+		for id, card := range pool {
+			assert.Equal(t, id, card.CardDetailID, c.name)
+			levels[id] = card.Level
+		}
+		assert.Equal(t, c.expected, levels, c.name)
+	}
+
+	// the highest level copy is kept with its uid
+	pool := simulator.GetCardPool(cardDetailMap, simulator.PlayerCollection{Cards: []CollectionCard{
+		{UID: "low", CardDetailID: 2, XP: 5, Edition: 7},
+		{UID: "high", CardDetailID: 2, XP: 30, Edition: 7},
+		{UID: "same", CardDetailID: 2, XP: 30, Edition: 7},
+	}})
+	assert.Equal(t, "high", pool[2].UID)
+	assert.True(t, pool.HasCard(2))
+	assert.Equal(t, 4, pool.GetLevel(2))
+	assert.Equal(t, 0, pool.GetLevel(3))
+}
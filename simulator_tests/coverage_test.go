@@ -0,0 +1,147 @@
+package simulator_tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	simulator "github.com/YukiUmetsu/go-spl-simulator"
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCardCoverage(t *testing.T) {
+	// known abilities are supported
+	cd := GetDefaultFakeMeleeOnlyCardDetail()
+	cd.ID = 1
+	cd.Stats.Abilities = []any{[]any{string(ABILITY_SHIELD)}, []any{}, []any{string(ABILITY_THORNS)}}
+	report, errs := CheckCardCoverage(CardDetailMap{cd.ID: cd})
+	assert.True(t, report.IsSupported())
+	assert.Equal(t, 0, len(errs))
+
+	// unknown abilities are reported with the cards that have them
+	cd2 := GetDefaultFakeMeleeOnlyCardDetail()
+	cd2.ID = 2
+	cd2.Stats.Abilities = []any{[]any{}, []any{"Unknown Ability"}}
+	report, _ = CheckCardCoverage(CardDetailMap{cd.ID: cd, cd2.ID: cd2})
+	assert.False(t, report.IsSupported())
+	assert.Equal(t, []Ability{"Unknown Ability"}, report.GetUnsupportedAbilities())
+	assert.Equal(t, []int{2}, report.UnsupportedAbilities["Unknown Ability"])
+
+	// malformed cards are returned as errors instead of being reported as supported
+	malformed := GetDefaultFakeMeleeOnlyCardDetail()
+	malformed.ID = 3
+	malformed.Stats.Health.([]any)[0] = "a lot"
+	malformed.Stats.Abilities = []any{[]any{"Unknown Ability"}}
+	report, errs = CheckCardCoverage(CardDetailMap{cd.ID: cd, malformed.ID: malformed})
+	assert.True(t, report.IsSupported())
+	assert.Equal(t, 1, len(errs))
+	decodeErr, ok := errs[0].(*CardDecodeError)
+	assert.True(t, ok)
+	assert.Equal(t, 3, decodeErr.CardDetailID)
+}
+
+/* Summoner 1, supported monster 2, monster 3 with an unknown ability, malformed monster 4 */
+func createFakeCoverageCatalog() CardDetailMap {
+	summoner := GetDefaultFakeSummoner().GetCardDetail()
+	summoner.ID = 1
+	supported := GetDefaultFakeMeleeOnlyCardDetail()
+	supported.ID = 2
+	unknown := GetDefaultFakeMeleeOnlyCardDetail()
+	unknown.ID, unknown.Name = 3, "Unknown"
+	unknown.Stats.Abilities = []any{[]any{"Unknown Ability"}}
+	malformed := GetDefaultFakeMeleeOnlyCardDetail()
+	malformed.ID, malformed.Name = 4, "Malformed"
+	malformed.Stats.Health.([]any)[0] = "a lot"
+	return CardDetailMap{1: summoner, 2: supported, 3: unknown, 4: malformed}
+}
+
+func createFakeCoverageBattle(monsterID int) BattleDetails {
+	team := BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 1}, Monsters: []CollectionCard{{CardDetailID: 2, Level: 1}}}
+	opponent := BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 1}, Monsters: []CollectionCard{{CardDetailID: monsterID, Level: 1}}}
+	return BattleDetails{Team1: team, Team2: opponent}
+}
+
+func TestCheckBattleCoverage(t *testing.T) {
+	cardDetailMap := createFakeCoverageCatalog()
+	cases := []struct {
+		name                 string
+		monsterID            int
+		rulesets             []Ruleset
+		expectedAbilities    []Ability
+		expectedRulesetCount int
+		expectedError        bool
+	}{
+		{"supported battle", 2, []Ruleset{RULESET_STANDARD}, []Ability{}, 0, false},
+		{"unknown ability", 3, []Ruleset{}, []Ability{"Unknown Ability"}, 0, false},
+		{"unknown ruleset", 2, []Ruleset{"Unknown Ruleset"}, []Ability{}, 1, false},
+		{"malformed card", 4, []Ruleset{}, []Ability{}, 0, true},
+	}
+	for _, c := range cases {
+		report, err := simulator.CheckBattleCoverage(cardDetailMap, createFakeCoverageBattle(c.monsterID), c.rulesets)
+		assert.Equal(t, c.expectedError, err != nil, c.name)
+		assert.Equal(t, c.expectedAbilities, report.GetUnsupportedAbilities(), c.name)
+		assert.Equal(t, c.expectedRulesetCount, len(report.UnsupportedRulesets), c.name)
+	}
+}
+
+func TestCreateCheckedGame(t *testing.T) {
+	cardDetailMap := createFakeCoverageCatalog()
+	cases := []struct {
+		name              string
+		monsterID         int
+		policy            simulator.CoveragePolicy
+		expectedCreated   bool
+		expectedSupported bool
+	}{
+		{"supported battle is created with refuse", 2, simulator.COVERAGE_POLICY_REFUSE, true, true},
+		{"unsupported battle is refused", 3, simulator.COVERAGE_POLICY_REFUSE, false, false},
+		{"unsupported battle is flagged", 3, simulator.COVERAGE_POLICY_FLAG, true, false},
+		{"malformed card", 4, simulator.COVERAGE_POLICY_FLAG, false, true},
+	}
+	for _, c := range cases {
+		game, report, err := simulator.CreateCheckedGame(cardDetailMap, createFakeCoverageBattle(c.monsterID), []Ruleset{}, false, c.policy)
+		assert.Equal(t, c.expectedCreated, err == nil, c.name)
+		assert.Equal(t, c.expectedCreated, game.GetTeam(TEAM_NUM_ONE) != nil, c.name)
+		assert.Equal(t, c.expectedSupported, report.IsSupported(), c.name)
+		if !c.expectedSupported && !c.expectedCreated {
+			_, ok := err.(*UnsupportedMechanicsError)
+			assert.True(t, ok, c.name)
+		}
+	}
+}
+
+func TestCheckCorpusCoverage(t *testing.T) {
+	createHistory := func(id string, monsterID int, ruleset string) BattleHistory {
+		details, _ := json.Marshal(createFakeCoverageBattle(monsterID))
+		return BattleHistory{BattleQueueId1: id, Ruleset: ruleset, Details: string(details)}
+	}
+	histories := []BattleHistory{
+		createHistory("supported", 2, "Standard"),
+		createHistory("unknown ability", 3, "Unknown Ruleset"),
+		createHistory("malformed", 4, ""),
+	}
+	report, errs := simulator.CheckCorpusCoverage(createFakeCoverageCatalog(), histories)
+	assert.Equal(t, []Ability{"Unknown Ability"}, report.GetUnsupportedAbilities())
+	assert.Equal(t, []int{3}, report.UnsupportedAbilities["Unknown Ability"])
+	assert.Equal(t, []Ruleset{"Unknown Ruleset"}, report.UnsupportedRulesets)
+	assert.Equal(t, 1, len(errs))
+	assert.Contains(t, errs[0].Error(), "battle malformed")
+}
+
+func TestCheckCoverage(t *testing.T) {
+	// every built in ruleset is supported
+	game, _, _ := CreateFakeGameAndTeams()
+	assert.True(t, game.CheckCoverage().IsSupported())
+
+	// unknown abilities of the cards and unknown rulesets are reported
+	var g Game
+	t1, t2 := CreateFakeGameTeam(), CreateFakeGameTeam()
+	t1.GetMonstersList()[0].AddAbility("Unknown Ability")
+	g.Create(t1, t2, []Ruleset{RULESET_STANDARD, "Unknown Ruleset"}, false)
+	report := g.CheckCoverage()
+	assert.Equal(t, []Ability{"Unknown Ability"}, report.GetUnsupportedAbilities())
+	assert.Equal(t, []Ruleset{"Unknown Ruleset"}, report.UnsupportedRulesets)
+
+	err := &UnsupportedMechanicsError{Report: report}
+	assert.Contains(t, err.Error(), "Unknown Ruleset")
+}
@@ -0,0 +1,114 @@
+package simulator_tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_CUSTOM_CARDS_YAML = `
+- name: Test Golem
+  color: Red
+  type: Monster
+  rarity: 4
+  stats:
+    mana: [7, 7, 7, 7]
+    attack: [3, 3, 4, 4]
+    ranged: [0, 0, 0, 0]
+    magic: [0, 0, 0, 0]
+    armor: [2, 2, 2, 3]
+    health: [8, 9, 10, 11]
+    speed: [1, 1, 1, 1]
+    abilities: [["Shield"], [], ["Thorns"]]
+- id: 1000100
+  name: Test Summoner
+  color: Red
+  type: Summoner
+  rarity: 2
+  stats:
+    mana: 4
+    attack: 1
+    ranged: 0
+    magic: 0
+    armor: 0
+    health: 0
+    speed: 0
+    abilities: []
+`
+
+func TestLoadCustomCards(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom_cards.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte(TEST_CUSTOM_CARDS_YAML), 0644))
+
+	definitions, err := LoadCustomCards(path)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(definitions))
+
+	official := GetDefaultFakeMeleeOnlyCardDetail()
+	official.Name = "Official Monster"
+	cardDetailMap, errs := MergeCustomCards(CardDetailMap{official.ID: official}, definitions)
+	assert.Empty(t, errs)
+	assert.Equal(t, 3, len(cardDetailMap))
+
+	// cards without an id get the first reserved id
+	golem := cardDetailMap[CUSTOM_CARD_FIRST_ID]
+	assert.Equal(t, "Test Golem", golem.Name)
+	assert.True(t, IsCustomCard(golem.ID))
+	assert.Equal(t, "Test Summoner", cardDetailMap[1000100].Name)
+
+	// custom cards are set up like official ones
+	var m MonsterCard
+	m.Setup(golem, 3)
+	assert.Equal(t, 4, m.Melee)
+	assert.Equal(t, 10, m.Health)
+	assert.True(t, m.HasAbility(ABILITY_SHIELD))
+	assert.True(t, m.HasAbility(ABILITY_THORNS))
+
+	var s SummonerCard
+	s.Setup(cardDetailMap[1000100], 1)
+	assert.Equal(t, 1, s.Melee)
+}
+
+func TestMergeCustomCards(t *testing.T) {
+	official := GetDefaultFakeMeleeOnlyCardDetail()
+	official.Name = "Official Monster"
+	cardDetailMap := CardDetailMap{official.ID: official}
+	monsterStats := GetDefaultFakeMeleeOnlyCardDetail().Stats
+
+	definitions := []CustomCardDefinition{
+		// id outside the reserved range
+		{ID: 5, Name: "Low Id", Color: COLOR_RED, Type: CARD_TYPE_MONSTER, Rarity: 1, Stats: monsterStats},
+		// name taken by an official card
+		{Name: "Official Monster", Color: COLOR_RED, Type: CARD_TYPE_MONSTER, Rarity: 1, Stats: monsterStats},
+		// monster with flat stats
+		{Name: "Flat Monster", Color: COLOR_RED, Type: CARD_TYPE_MONSTER, Rarity: 1, Stats: GetDefaultFakeSummoner().GetCardDetail().Stats},
+		// unknown rarity
+		{Name: "Mythic", Color: COLOR_RED, Type: CARD_TYPE_MONSTER, Rarity: 5, Stats: monsterStats},
+		// unknown and missing colors
+		{Name: "Misspelled", Color: "Redd", Type: CARD_TYPE_MONSTER, Rarity: 1, Stats: monsterStats},
+		{Name: "Colorless", Type: CARD_TYPE_MONSTER, Rarity: 1, Stats: monsterStats},
+		// malformed editions
+		{Name: "No Set", Color: COLOR_RED, Type: CARD_TYPE_MONSTER, Rarity: 1, Editions: "Rebellion", Stats: monsterStats},
+		{Name: "Valid", Color: COLOR_RED, Type: CARD_TYPE_MONSTER, Rarity: 1, Stats: monsterStats},
+		{Name: "Untamed Valid", Color: COLOR_RED, Type: CARD_TYPE_MONSTER, Rarity: 1, Editions: "4", Stats: monsterStats},
+	}
+	merged, errs := MergeCustomCards(cardDetailMap, definitions)
+	assert.Equal(t, 7, len(errs))
+	assert.Equal(t, 3, len(merged))
+	assert.Equal(t, "Valid", merged[CUSTOM_CARD_FIRST_ID].Name)
+	for i, field := range map[int]string{4: "color", 5: "color", 6: "editions"} {
+		decodeErr, ok := errs[i].(*CardDecodeError)
+		assert.True(t, ok)
+		assert.Equal(t, field, decodeErr.Field)
+	}
+
+	// custom cards without editions are legal in Modern, others keep their editions
+	assert.Equal(t, []CardEdition{CUSTOM_CARD_DEFAULT_EDITION}, GetEditionsOfCard(merged[CUSTOM_CARD_FIRST_ID]))
+	assert.True(t, NewModernFormat().IsCardLegal(merged[CUSTOM_CARD_FIRST_ID]))
+	assert.False(t, NewModernFormat().IsCardLegal(merged[CUSTOM_CARD_FIRST_ID+1]))
+	// the catalog is not modified
+	assert.Equal(t, 1, len(cardDetailMap))
+}
@@ -236,3 +236,46 @@ func TestHitMonsterWithPhysical(t *testing.T) {
 		}
 	}
 }
+
+func TestHitMonsterDamageCalculation(t *testing.T) {
+	// no calculation is built when nobody reads it
+	target := GetDefaultFakeMonster(ATTACK_TYPE_MELEE)
+	battleDamage := HitMonsterWithPhysical(CreateFakeGame(), target, 3)
+	assert.Nil(t, battleDamage.Calculation)
+	assert.Equal(t, 3, battleDamage.DamageDone)
+
+	game, _, _ := CreateFakeLoggingGameAndTeams()
+
+	// records the armor and health split
+	target = GetDefaultFakeMonster(ATTACK_TYPE_MELEE)
+	battleDamage = HitMonsterWithPhysical(game, target, 3)
+	assert.Equal(t, 3, battleDamage.Calculation.IncomingDamage)
+	assert.Equal(t, 3, battleDamage.Calculation.ArmorDamage)
+	assert.Equal(t, 0, battleDamage.Calculation.HealthDamage)
+
+	// records the remainder when the armor breaks
+	target = GetDefaultFakeMonster(ATTACK_TYPE_MELEE)
+	battleDamage = HitMonsterWithPhysical(game, target, TEST_DEFAULT_ARMOR+2)
+	assert.Equal(t, TEST_DEFAULT_ARMOR, battleDamage.Calculation.ArmorDamage)
+	assert.Equal(t, 2, battleDamage.Calculation.Remainder)
+
+	// records the shield reduction
+	target = GetDefaultFakeMonsterWithAbility(ATTACK_TYPE_MELEE, []Ability{ABILITY_SHIELD})
+	target.Armor = 0
+	battleDamage = HitMonsterWithPhysical(game, target, 3)
+	assert.Equal(t, []DamageReduction{{Ability: ABILITY_SHIELD, Before: 3, After: 2}}, battleDamage.Calculation.Reductions)
+	assert.Equal(t, 2, battleDamage.Calculation.HealthDamage)
+
+	// records forcefield and void reductions in order
+	target = GetDefaultFakeMonsterWithAbility(ATTACK_TYPE_MAGIC, []Ability{ABILITY_FORCEFIELD, ABILITY_VOID})
+	battleDamage = HitMonsterWithMagic(game, target, 6)
+	assert.Equal(t, 2, len(battleDamage.Calculation.Reductions))
+	assert.Equal(t, Ability(ABILITY_FORCEFIELD), battleDamage.Calculation.Reductions[0].Ability)
+	assert.Equal(t, Ability(ABILITY_VOID), battleDamage.Calculation.Reductions[1].Ability)
+
+	// records divine shield absorption
+	target = GetDefaultFakeMonsterWithAbility(ATTACK_TYPE_MAGIC, []Ability{ABILITY_DIVINE_SHIELD})
+	battleDamage = HitMonsterWithMagic(game, target, 3)
+	assert.True(t, battleDamage.Calculation.DivineShieldAbsorbed)
+	assert.Equal(t, 0, battleDamage.Calculation.GetTotalDamage())
+}
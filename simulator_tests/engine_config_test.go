@@ -0,0 +1,75 @@
+package simulator_tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadEngineConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		assert.Nil(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	// json, missing parameters keep their default value
+	config, err := LoadEngineConfig(writeFile("config.json", `{"thorns_damage": 3, "dodge_chance": 0.2}`))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, config.ThornsDamage)
+	assert.Equal(t, 0.2, config.DodgeChance)
+	assert.Equal(t, FATIGUE_ROUND_NUMBER, config.FatigueRoundNumber)
+
+	// yaml
+	config, err = LoadEngineConfig(writeFile("config.yaml", "fatigue_round_number: 15\nblast_multiplier: 1\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, 15, config.FatigueRoundNumber)
+	assert.Equal(t, float64(1), config.BlastMultiplier)
+	assert.Equal(t, THORNS_DAMAGE, config.ThornsDamage)
+
+	// malformed file
+	_, err = LoadEngineConfig(writeFile("broken.json", `{"thorns_damage": "a lot"}`))
+	assert.NotNil(t, err)
+
+	// missing file
+	_, err = LoadEngineConfig(filepath.Join(dir, "missing.json"))
+	assert.NotNil(t, err)
+}
+
+func TestCreateWithConfig(t *testing.T) {
+	var game *Game
+	var attacker *MonsterCard
+	var target *MonsterCard
+
+	setUp := func(config EngineConfig) {
+		game = &Game{}
+		t1, t2 := CreateFakeGameTeam(), CreateFakeGameTeam()
+		game.CreateWithConfig(t1, t2, []Ruleset{RULESET_STANDARD}, false, config)
+		attacker = t1.GetFirstAliveMonster()
+		target = t2.GetFirstAliveMonster()
+		target.AddAbility(ABILITY_THORNS)
+	}
+
+	// default config
+	var defaultGame Game
+	assert.Equal(t, DefaultEngineConfig(), defaultGame.GetConfig())
+
+	// thorns damage of the config
+	config := DefaultEngineConfig()
+	config.ThornsDamage = 3
+	setUp(config)
+	game.MaybeApplyThorns(attacker, target, ATTACK_TYPE_MELEE)
+	assert.Equal(t, TEST_DEFAULT_ARMOR-3, attacker.GetArmor())
+
+	// dodge chance of the config
+	config = DefaultEngineConfig()
+	config.DodgeChance = 1
+	target = GetDefaultFakeMonsterWithAbility(ATTACK_TYPE_MELEE, []Ability{ABILITY_DODGE})
+	attacker = GetDefaultFakeMonster(ATTACK_TYPE_MELEE)
+	assert.Equal(t, float64(1), GetDodgeChanceWithConfig(config, []Ruleset{RULESET_STANDARD}, attacker, target, ATTACK_TYPE_MELEE))
+	assert.Equal(t, DODGE_CHANCE, GetDodgeChance([]Ruleset{RULESET_STANDARD}, attacker, target, ATTACK_TYPE_MELEE))
+}
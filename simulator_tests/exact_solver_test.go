@@ -0,0 +1,168 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingRandomSource struct {
+	labels []string
+}
+
+func (s *recordingRandomSource) Chance(label string, p float64) bool {
+	s.labels = append(s.labels, label)
+	return p >= 0.5
+}
+
+func (s *recordingRandomSource) Pick(label string, n int) int {
+	s.labels = append(s.labels, label)
+	return 0
+}
+
+/* Returns a function creating the same new game of fake teams each time */
+func createFakeGameFactory(team1Details, team2Details []CardDetail) func() *Game {
+	return createFakeGameFactoryWithRulesets([]Ruleset{}, team1Details, team2Details)
+}
+
+func createFakeGameFactoryWithRulesets(rulesets []Ruleset, team1Details, team2Details []CardDetail) func() *Game {
+	summonerDetail := GetDefaultFakeSummoner().GetCardDetail()
+	createTeam := func(details []CardDetail, player string) *GameTeam {
+		var s SummonerCard
+		(&s).Setup(summonerDetail, 4)
+		monsters := make([]*MonsterCard, 0)
+		for _, detail := range details {
+			var m MonsterCard
+			(&m).Setup(detail, 4)
+			monsters = append(monsters, &m)
+		}
+		var t GameTeam
+		(&t).Create(&s, monsters, player)
+		return &t
+	}
+	return func() *Game {
+		var game Game
+		(&game).Create(createTeam(team1Details, "test_player1"), createTeam(team2Details, "test_player2"), rulesets, false)
+		return &game
+	}
+}
+
+func TestGetSuccessBelowProbability(t *testing.T) {
+	assert.Equal(t, 0.0, GetSuccessBelowProbability(0))
+	assert.Equal(t, 0.0, GetSuccessBelowProbability(-5))
+	assert.Equal(t, 50.0/101, GetSuccessBelowProbability(50))
+	assert.Equal(t, 50.0/101, GetSuccessBelowProbability(49.5))
+	assert.Equal(t, 1.0, GetSuccessBelowProbability(101))
+	assert.Equal(t, 1.0, GetSuccessBelowProbability(150))
+}
+
+func TestSetRandomSource(t *testing.T) {
+	game := createFakeGameFactory(
+		[]CardDetail{GetDefaultFakeMeleeOnlyCardDetail(), GetDefaultFakeRangeOnlyCardDetail()},
+		[]CardDetail{GetDefaultFakeMeleeOnlyCardDetail(), GetDefaultFakeRangeOnlyCardDetail()},
+	)()
+	assert.Equal(t, MathRandomSource{}, game.GetRandomSource())
+
+	source := &recordingRandomSource{}
+	game.SetRandomSource(source)
+	game.PlayGame()
+	// monsters of the same speed break their ties with the source
+	assert.Contains(t, source.labels, RANDOM_EVENT_TIE_BREAK)
+	assert.NotEqual(t, TEAM_NUM_UNKNOWN, game.GetWinner())
+}
+
+func TestExactSolverSolve(t *testing.T) {
+	setUp := func() *ExactSolver {
+		return NewExactSolver(createFakeGameFactory(
+			[]CardDetail{GetDefaultFakeMeleeOnlyCardDetail(), GetDefaultFakeMagicOnlyCardDetail()},
+			[]CardDetail{GetDefaultFakeMeleeOnlyCardDetail(), GetDefaultFakeMagicOnlyCardDetail()},
+		))
+	}
+
+	result := setUp().Solve()
+	assert.True(t, result.IsExact)
+	assert.Equal(t, 0, result.SampledGames)
+	p := result.Probabilities
+	assert.InDelta(t, 1, p.Team1Win+p.Team2Win+p.Tie, 1e-9)
+	// the teams are the same
+	assert.InDelta(t, p.Team1Win, p.Team2Win, 1e-9)
+	assert.Equal(t, p.Team1Win, p.GetWinProbability(TEAM_NUM_ONE))
+	assert.Equal(t, p.Tie, p.GetWinProbability(TEAM_NUM_TIE))
+
+	// the results of the same states are reused
+	assert.Greater(t, result.MemoHits, 0)
+
+	// same result solved again
+	assert.Equal(t, p, setUp().Solve().Probabilities)
+}
+
+func TestExactSolverSolveFallback(t *testing.T) {
+	solver := NewExactSolver(createFakeGameFactory(
+		[]CardDetail{GetDefaultFakeMeleeOnlyCardDetail(), GetDefaultFakeMagicOnlyCardDetail()},
+		[]CardDetail{GetDefaultFakeMeleeOnlyCardDetail(), GetDefaultFakeMagicOnlyCardDetail()},
+	))
+	solver.Budget = 1
+	solver.FallbackIterations = 20
+
+	result := solver.Solve()
+	assert.False(t, result.IsExact)
+	assert.Equal(t, 20, result.SampledGames)
+	p := result.Probabilities
+	assert.InDelta(t, 1, p.Team1Win+p.Team2Win+p.Tie, 1e-9)
+}
+
+func TestExactSolverSolveCertainWin(t *testing.T) {
+	// the draws only decide who acts first against a monster that can't attack
+	solver := NewExactSolver(createFakeGameFactory(
+		[]CardDetail{GetDefaultFakeMeleeOnlyCardDetail()},
+		[]CardDetail{GetDefaultFakeNoAttackCardDetail()},
+	))
+	result := solver.Solve()
+	assert.True(t, result.IsExact)
+	assert.Equal(t, OutcomeProbabilities{Team1Win: 1}, result.Probabilities)
+}
+
+func createFakeMeleeCardDetail(name string, health, armor, speed, melee int) CardDetail {
+	perLevel := func(stat int) []any {
+		return []any{stat, stat, stat, stat, stat, stat, stat, stat}
+	}
+	detail := GetDefaultFakeMeleeOnlyCardDetail()
+	detail.Name = name
+	detail.Stats.Health = perLevel(health)
+	detail.Stats.Armor = perLevel(armor)
+	detail.Stats.Speed = perLevel(speed)
+	detail.Stats.Attack = perLevel(melee)
+	return detail
+}
+
+func TestExactSolverSolveKnownProbability(t *testing.T) {
+	// the runner is faster and hits first, then dodges the striker's lethal hit with a 10% chance.
+	// if it dodges, its second hit kills the striker.
+	solver := NewExactSolver(createFakeGameFactoryWithRulesets(
+		[]Ruleset{Ruleset(RULESET_STANDARD)},
+		[]CardDetail{createFakeMeleeCardDetail("Striker", 2, 0, 5, 10)},
+		[]CardDetail{createFakeMeleeCardDetail("Runner", 5, 0, 6, 1)},
+	))
+	result := solver.Solve()
+	assert.True(t, result.IsExact)
+	dodge := GetSuccessBelowProbability(SPEED_DIFF_DODGE_CHANCE * 100)
+	assert.InDelta(t, 10.0/101, dodge, 1e-9)
+	assert.InDelta(t, 1-dodge, result.Probabilities.Team1Win, 1e-9)
+	assert.InDelta(t, dodge, result.Probabilities.Team2Win, 1e-9)
+	assert.InDelta(t, 0, result.Probabilities.Tie, 1e-9)
+}
+
+func TestExactSolverSolveDefaults(t *testing.T) {
+	solver := NewExactSolver(createFakeGameFactory(
+		[]CardDetail{GetDefaultFakeMeleeOnlyCardDetail()},
+		[]CardDetail{GetDefaultFakeNoAttackCardDetail()},
+	))
+	solver.Budget = 0
+	solver.FallbackIterations = -1
+	result := solver.Solve()
+	assert.True(t, result.IsExact)
+	assert.Equal(t, EXACT_SOLVER_DEFAULT_BUDGET, solver.Budget)
+	assert.Equal(t, EXACT_SOLVER_DEFAULT_FALLBACK_ITERATIONS, solver.FallbackIterations)
+	assert.Equal(t, OutcomeProbabilities{Team1Win: 1}, result.Probabilities)
+}
@@ -194,3 +194,113 @@ func TestMaybeApplyReturnFire(t *testing.T) {
 	game.MaybeApplyReturnFire(attacker, target, ATTACK_TYPE_RANGED, 3)
 	assert.Equal(t, TEST_DEFAULT_ARMOR-1, attacker.GetArmor())
 }
+
+func TestGetDamageCalculations(t *testing.T) {
+	var game *Game
+	var t1 *GameTeam
+	var t2 *GameTeam
+	var attacker *MonsterCard
+	var target *MonsterCard
+
+	setUp := func() {
+		game, t1, t2 = CreateFakeLoggingGameAndTeams()
+		attacker = t1.GetFirstAliveMonster()
+		target = t2.GetFirstAliveMonster()
+	}
+
+	// returns an error if the game doesn't log
+	nonLoggingGame, _, _ := CreateFakeGameAndTeams()
+	_, err := nonLoggingGame.GetDamageCalculations()
+	assert.NotNil(t, err)
+
+	// records the attack side of a melee attack
+	setUp()
+	attacker.AddSummonerMelee(1)
+	attacker.AddBuff(ABILITY_INSPIRE)
+	game.AttackMonsterPhase(attacker, target, ATTACK_TYPE_MELEE)
+	calculations, err := game.GetDamageCalculations()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(calculations))
+	calc := calculations[0]
+	assert.Equal(t, BATTLE_ACTION_MELEE, calc.Source)
+	assert.Equal(t, TEST_DEFAULT_ATTACK, calc.BaseAttack)
+	assert.Equal(t, 1, calc.SummonerBonus)
+	assert.Equal(t, 1, calc.ModifierBonus)
+	assert.Equal(t, []Ability{ABILITY_INSPIRE}, calc.Modifiers)
+	assert.Equal(t, TEST_DEFAULT_ATTACK+2, calc.PostAbilityAttack)
+	assert.Equal(t, TEST_DEFAULT_ARMOR, calc.ArmorDamage)
+	assert.Equal(t, 2, calc.Remainder)
+
+	// records the multiplier abilities
+	setUp()
+	attacker.AddAbility(ABILITY_KNOCK_OUT)
+	target.AddDebuff(ABILITY_STUN)
+	game.AttackMonsterPhase(attacker, target, ATTACK_TYPE_MELEE)
+	calculations, _ = game.GetDamageCalculations()
+	assert.Equal(t, 2, calculations[0].Multiplier)
+	assert.Equal(t, []Ability{ABILITY_KNOCK_OUT}, calculations[0].MultiplierAbilities)
+
+	// records piercing, life leech and thorns
+	setUp()
+	attacker.AddSummonerMelee(2)
+	attacker.AddAbility(ABILITY_PIERCING)
+	attacker.AddAbility(ABILITY_LIFE_LEECH)
+	target.AddAbility(ABILITY_THORNS)
+	game.AttackMonsterPhase(attacker, target, ATTACK_TYPE_MELEE)
+	calculations, _ = game.GetDamageCalculations()
+	assert.Equal(t, 2, len(calculations))
+	assert.Equal(t, BATTLE_ACTION_THORNS, calculations[1].Source)
+	calc = calculations[0]
+	assert.Equal(t, 2, calc.PiercingDamage)
+	assert.Equal(t, 2, len(calc.SideEffects))
+	assert.Equal(t, Ability(ABILITY_LIFE_LEECH), calc.SideEffects[0].Ability)
+	assert.Equal(t, 3, calc.SideEffects[0].Amount)
+	assert.Equal(t, Ability(ABILITY_THORNS), calc.SideEffects[1].Ability)
+	assert.Equal(t, 2, calc.SideEffects[1].Amount)
+	// side effect cards are kept as they were, like the attacker and the target
+	assert.NotSame(t, attacker, calc.SideEffects[0].Card)
+	assert.Equal(t, TEST_DEFAULT_ARMOR, calc.SideEffects[0].Card.(*MonsterCard).GetArmor())
+	assert.Equal(t, TEST_DEFAULT_ARMOR-2, attacker.GetArmor())
+
+	// records the magic reflect and life leech of a blast
+	setUp()
+	attacker = t1.GetMonstersList()[1]
+	attacker.AddAbility(ABILITY_BLAST)
+	attacker.AddAbility(ABILITY_LIFE_LEECH)
+	blastTarget := t2.GetMonstersList()[1]
+	blastTarget.AddAbility(ABILITY_MAGIC_REFLECT)
+	game.MaybeApplyBlast(attacker, blastTarget, ATTACK_TYPE_MAGIC, 4)
+	calculations, _ = game.GetDamageCalculations()
+	assert.Equal(t, BATTLE_ACTION_BLAST, calculations[0].Source)
+	assert.Equal(t, 2, calculations[0].HealthDamage)
+	assert.Equal(t, 2, len(calculations[0].SideEffects))
+	assert.Equal(t, Ability(ABILITY_MAGIC_REFLECT), calculations[0].SideEffects[0].Ability)
+	assert.Equal(t, 1, calculations[0].SideEffects[0].Amount)
+	assert.Equal(t, Ability(ABILITY_LIFE_LEECH), calculations[0].SideEffects[1].Ability)
+	assert.Equal(t, 1, calculations[0].SideEffects[1].Amount)
+
+	// records divine shield absorption of the attack
+	setUp()
+	target.AddAbility(ABILITY_DIVINE_SHIELD)
+	game.AttackMonsterPhase(attacker, target, ATTACK_TYPE_MELEE)
+	calculations, _ = game.GetDamageCalculations()
+	assert.True(t, calculations[0].DivineShieldAbsorbed)
+	assert.Equal(t, TEST_DEFAULT_ATTACK, calculations[0].IncomingDamage)
+}
+
+func TestAddPreGameHook(t *testing.T) {
+	game, t1, _ := CreateFakeGameAndTeams()
+	t1.GetMonstersList()[0].AddAbility(ABILITY_THORNS)
+
+	// hooks run after the cards are reset
+	hookCalls := 0
+	game.AddPreGameHook(func(g *Game) {
+		hookCalls += 1
+		assert.Equal(t, t1, g.GetTeam(TEAM_NUM_ONE))
+		g.GetTeam(TEAM_NUM_ONE).GetMonstersList()[1].AddAbility(ABILITY_VOID)
+	})
+	game.PlayGame()
+	assert.Equal(t, 1, hookCalls)
+	assert.False(t, t1.GetMonstersList()[0].HasAbility(ABILITY_THORNS))
+	assert.True(t, t1.GetMonstersList()[1].HasAbility(ABILITY_VOID))
+}
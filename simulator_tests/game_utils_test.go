@@ -265,3 +265,17 @@ func TestNormalCompareAttackOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestGetMaxLevelOfCard(t *testing.T) {
+	// monsters have a stat per level
+	assert.Equal(t, 8, GetMaxLevelOfCard(GetDefaultFakeMeleeOnlyCardDetail()))
+
+	// summoners use the max level of the rarity
+	summonerDetail := GetDefaultFakeSummoner().GetCardDetail()
+	summonerDetail.Rarity = 3
+	assert.Equal(t, 6, GetMaxLevelOfCard(summonerDetail))
+
+	assert.Equal(t, 10, GetMaxLevelOfRarity(1))
+	assert.Equal(t, 4, GetMaxLevelOfRarity(4))
+	assert.Equal(t, 0, GetMaxLevelOfRarity(5))
+}
@@ -0,0 +1,182 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	simulator "github.com/YukiUmetsu/go-spl-simulator"
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLevelCap(t *testing.T) {
+	settings := NewMatchSettings()
+	assert.Equal(t, 0, settings.GetLevelCap(1))
+
+	settings.RatingLevel = RATING_LEVEL_SILVER
+	assert.Equal(t, 5, settings.GetLevelCap(1))
+	assert.Equal(t, 2, settings.GetLevelCap(4))
+
+	settings.RatingLevel = RATING_LEVEL_CHAMPION
+	assert.Equal(t, 10, settings.GetLevelCap(1))
+	assert.Equal(t, 0, settings.GetLevelCap(5))
+}
+
+func TestValidateBattleTeam(t *testing.T) {
+	setUp := func() (CardDetailMap, BattleTeam) {
+		summoner := GetDefaultFakeSummoner().GetCardDetail()
+		summoner.ID = 1
+		summoner.Color = COLOR_RED
+		monster := GetDefaultFakeMeleeOnlyCardDetail()
+		monster.ID = 2
+		monster.Color = COLOR_GRAY
+		cardDetailMap := CardDetailMap{summoner.ID: summoner, monster.ID: monster}
+		battleTeam := BattleTeam{
+			Summoner: CollectionCard{CardDetailID: summoner.ID, Level: 1},
+			Monsters: []CollectionCard{{CardDetailID: monster.ID, Level: 1, Gold: true}},
+		}
+		return cardDetailMap, battleTeam
+	}
+
+	// no restrictions
+	cardDetailMap, battleTeam := setUp()
+	settings := NewMatchSettings()
+	assert.Empty(t, settings.ValidateBattleTeam(cardDetailMap, battleTeam))
+
+	// inactive splinter, neutral monsters are always allowed
+	settings.InactiveColors = []CardColor{COLOR_RED, COLOR_GRAY}
+	errs := settings.ValidateBattleTeam(cardDetailMap, battleTeam)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, 1, errs[0].(*MatchRestrictionError).CardDetailID)
+
+	// gold foil only
+	settings = NewMatchSettings()
+	settings.AllowedFoil = ALLOWED_FOIL_GOLD_ONLY
+	errs = settings.ValidateBattleTeam(cardDetailMap, battleTeam)
+	assert.Equal(t, 1, len(errs))
+	assert.False(t, settings.IsCardAllowed(cardDetailMap[1], battleTeam.Summoner))
+	assert.True(t, settings.IsCardAllowed(cardDetailMap[2], battleTeam.Monsters[0]))
+
+	// allowed editions
+	settings = NewMatchSettings()
+	settings.Format = NewCustomFormat("Chaos Brawl", BattleAllowedCards{Editions: []int{int(CHAOS)}})
+	errs = settings.ValidateBattleTeam(cardDetailMap, battleTeam)
+	assert.Equal(t, 2, len(errs))
+}
+
+func TestApplyMatchLevelCap(t *testing.T) {
+	cardDetailMap := createFakeCappedCatalog()
+	team := BattleTeam{
+		Summoner: CollectionCard{CardDetailID: 1, Level: 4},
+		Monsters: []CollectionCard{{CardDetailID: 2, Level: 8}, {CardDetailID: 3, Level: 3}},
+	}
+
+	cases := []struct {
+		name           string
+		ratingLevel    int
+		expectedLevels []int // summoner, common, legendary
+		expected       []LevelCapAdjustment
+	}{
+		{"not capped", -1, []int{4, 8, 3}, []LevelCapAdjustment{}},
+		{"novice caps everything at 1", RATING_LEVEL_NOVICE, []int{1, 1, 1}, []LevelCapAdjustment{
+			{Position: SUMMONER_POSITION, CardDetailID: 1, CardName: "Legendary Summoner", Level: 4, CappedLevel: 1},
+			{Position: 0, CardDetailID: 2, CardName: "Common Monster", Level: 8, CappedLevel: 1},
+			{Position: 1, CardDetailID: 3, CardName: "Legendary Monster", Level: 3, CappedLevel: 1},
+		}},
+		{"silver", RATING_LEVEL_SILVER, []int{2, 5, 2}, []LevelCapAdjustment{
+			{Position: SUMMONER_POSITION, CardDetailID: 1, CardName: "Legendary Summoner", Level: 4, CappedLevel: 2},
+			{Position: 0, CardDetailID: 2, CardName: "Common Monster", Level: 8, CappedLevel: 5},
+			{Position: 1, CardDetailID: 3, CardName: "Legendary Monster", Level: 3, CappedLevel: 2},
+		}},
+		{"gold caps only the common", RATING_LEVEL_GOLD, []int{3, 8, 3}, []LevelCapAdjustment{
+			{Position: SUMMONER_POSITION, CardDetailID: 1, CardName: "Legendary Summoner", Level: 4, CappedLevel: 3},
+		}},
+		{"champion", RATING_LEVEL_CHAMPION, []int{4, 8, 3}, []LevelCapAdjustment{}},
+	}
+	for _, c := range cases {
+		settings := NewMatchSettings()
+		settings.RatingLevel = c.ratingLevel
+		capped, adjustments := ApplyMatchLevelCap(cardDetailMap, team, settings)
+		assert.Equal(t, c.expected, adjustments, c.name)
+		assert.Equal(t, c.expectedLevels, []int{capped.Summoner.Level, capped.Monsters[0].Level, capped.Monsters[1].Level}, c.name)
+	}
+	// the team is not modified
+	assert.Equal(t, 4, team.Summoner.Level)
+	assert.Equal(t, 8, team.Monsters[0].Level)
+}
+
+func TestCreateMatchGame(t *testing.T) {
+	cardDetailMap := createFakeCappedCatalog()
+	team1 := BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 4}, Monsters: []CollectionCard{{CardDetailID: 2, Level: 8}}}
+	team2 := BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 1}, Monsters: []CollectionCard{{CardDetailID: 3, Level: 4}}}
+	battleDetails := BattleDetails{Team1: team1, Team2: team2}
+
+	cases := []struct {
+		name                     string
+		ratingLevel              int
+		team1Levels, team2Levels []int // summoner, monster
+	}{
+		// only the match cap is applied: the level 1 summoner doesn't cap the monster of team 2
+		{"not capped", -1, []int{4, 8}, []int{1, 4}},
+		{"bronze", RATING_LEVEL_BRONZE, []int{1, 3}, []int{1, 1}},
+		{"diamond", RATING_LEVEL_DIAMOND, []int{4, 8}, []int{1, 4}},
+	}
+	for _, c := range cases {
+		settings := NewMatchSettings()
+		settings.RatingLevel = c.ratingLevel
+		game, err := simulator.CreateMatchGame(cardDetailMap, battleDetails, []Ruleset{}, settings, false)
+		assert.Nil(t, err, c.name)
+		for teamNumber, expected := range map[TeamNumber][]int{TEAM_NUM_ONE: c.team1Levels, TEAM_NUM_TWO: c.team2Levels} {
+			team := game.GetTeam(teamNumber)
+			// summoner card levels start at 0
+			assert.Equal(t, expected, []int{team.GetSummoner().GetCardLevel() + 1, team.GetMonstersList()[0].CardLevel}, c.name)
+		}
+	}
+	// the battle is not modified
+	assert.Equal(t, 4, battleDetails.Team1.Summoner.Level)
+}
+
+func TestParseMatchSettings(t *testing.T) {
+	cases := []struct {
+		name     string
+		battle   BattleHistory
+		expected func() MatchSettings
+		isError  bool
+	}{
+		{"ranked without restrictions", BattleHistory{}, NewMatchSettings, false},
+		{"inactive colors", BattleHistory{Inactive: "Red, Blue,"}, func() MatchSettings {
+			settings := NewMatchSettings()
+			settings.InactiveColors = []CardColor{COLOR_RED, COLOR_BLUE}
+			return settings
+		}, false},
+		{"rating level", BattleHistory{Settings: `{"rating_level":2}`}, func() MatchSettings {
+			settings := NewMatchSettings()
+			settings.RatingLevel = RATING_LEVEL_SILVER
+			return settings
+		}, false},
+		{"brawl", BattleHistory{Inactive: "Black", Settings: `{"rating_level":4,"allowed_cards":{"foil":"gold_only","editions":[7,8]}}`}, func() MatchSettings {
+			settings := NewMatchSettings()
+			settings.RatingLevel = RATING_LEVEL_DIAMOND
+			settings.AllowedFoil = ALLOWED_FOIL_GOLD_ONLY
+			settings.Format = NewCustomFormat("Allowed Editions", BattleAllowedCards{Editions: []int{7, 8}})
+			settings.InactiveColors = []CardColor{COLOR_BLACK}
+			return settings
+		}, false},
+		{"tournament", BattleHistory{Settings: `{"allowed_cards":{"foil":"all","type":"no_legendaries","editions":[]}}`}, func() MatchSettings {
+			settings := NewMatchSettings()
+			settings.AllowedType = ALLOWED_TYPE_NO_LEGENDARIES
+			return settings
+		}, false},
+		{"unknown foil", BattleHistory{Settings: `{"allowed_cards":{"foil":"black_only"}}`}, nil, true},
+		{"unknown card type", BattleHistory{Settings: `{"allowed_cards":{"type":"commons_only"}}`}, nil, true},
+		{"malformed settings", BattleHistory{Settings: `{"rating_level":`}, nil, true},
+	}
+	for _, c := range cases {
+		settings, err := simulator.ParseMatchSettings(c.battle)
+		if c.isError {
+			assert.NotNil(t, err, c.name)
+			continue
+		}
+		assert.Nil(t, err, c.name)
+		assert.Equal(t, c.expected(), settings, c.name)
+	}
+}
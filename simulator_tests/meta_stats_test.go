@@ -0,0 +1,85 @@
+package simulator_tests
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetManaBracket(t *testing.T) {
+	assert.Equal(t, "0-20", GetManaBracket(12))
+	assert.Equal(t, "0-20", GetManaBracket(20))
+	assert.Equal(t, "21-30", GetManaBracket(21))
+	assert.Equal(t, "31-45", GetManaBracket(45))
+	assert.Equal(t, "46+", GetManaBracket(99))
+}
+
+func TestMetaStatsGetReport(t *testing.T) {
+	setUp := func() *MetaStats {
+		cardDetailMap := CardDetailMap{1: {ID: 1, Name: "summoner"}, 2: {ID: 2, Name: "a"}, 3: {ID: 3, Name: "b"}, 4: {ID: 4, Name: "c"}}
+		team := func(summonerID int, monsterIDs ...int) BattleTeam {
+			battleTeam := BattleTeam{Summoner: CollectionCard{CardDetailID: summonerID}}
+			for _, id := range monsterIDs {
+				battleTeam.Monsters = append(battleTeam.Monsters, CollectionCard{CardDetailID: id})
+			}
+			return battleTeam
+		}
+		stats := NewMetaStats(cardDetailMap)
+		stats.AddBattle(MetaBattle{ManaCap: 15, Rulesets: []Ruleset{Ruleset(RULESET_EARTHQUAKE)}, Teams: []MetaTeam{
+			{Team: team(1, 3, 2), Rating: 1000, Won: true},
+			{Team: team(1, 4), Rating: 2000},
+		}})
+		stats.AddBattle(MetaBattle{ManaCap: 50, Teams: []MetaTeam{
+			{Team: team(1, 2, 4), Rating: 3000},
+			{Team: team(1, 2, 3), Rating: 1000, Won: true},
+		}})
+		return stats
+	}
+
+	report := setUp().GetReport()
+	// every battle, 2 mana brackets, the ruleset and the ruleset in its mana bracket
+	assert.Equal(t, 5, len(report.Groups))
+	assert.Equal(t, Ruleset(""), report.Groups[0].Ruleset)
+	assert.Equal(t, "", report.Groups[0].ManaBracket)
+	assert.Equal(t, "0-20", report.Groups[1].ManaBracket)
+
+	all, ok := report.GetGroup("", "")
+	assert.True(t, ok)
+	assert.Equal(t, 2, all.Battles)
+	assert.Equal(t, 4, all.Teams)
+	assert.Equal(t, CardMetaStats{CardDetailID: 1, Name: "summoner", Played: 4, Wins: 2, Winrate: 0.5, PlayRate: 1, AverageRating: 1750}, all.Summoners[0])
+	assert.Equal(t, CardMetaStats{CardDetailID: 2, Name: "a", Played: 3, Wins: 2, Winrate: 2.0 / 3, PlayRate: 0.75, AverageRating: 5000.0 / 3}, all.Monsters[0])
+	assert.Equal(t, [2]int{2, 3}, all.Pairings[0].CardDetailIDs)
+	assert.Equal(t, [2]string{"a", "b"}, all.Pairings[0].Names)
+	assert.Equal(t, 2, all.Pairings[0].Played)
+	assert.Equal(t, 1.0, all.Pairings[0].Winrate)
+
+	earthquake, ok := report.GetGroup(Ruleset(RULESET_EARTHQUAKE), "0-20")
+	assert.True(t, ok)
+	assert.Equal(t, 1, earthquake.Battles)
+	_, ok = report.GetGroup(Ruleset(RULESET_EARTHQUAKE), "46+")
+	assert.False(t, ok)
+
+	// JSON
+	var buf bytes.Buffer
+	assert.Nil(t, report.WriteJSON(&buf))
+	var decoded MetaReport
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, report, decoded)
+
+	// CSV: a row per summoner, monster and pairing of every group
+	buf.Reset()
+	assert.Nil(t, report.WriteCSV(&buf))
+	rows, err := csv.NewReader(&buf).ReadAll()
+	assert.Nil(t, err)
+	expectedRows := 1
+	for _, group := range report.Groups {
+		expectedRows += len(group.Summoners) + len(group.Monsters) + len(group.Pairings)
+	}
+	assert.Equal(t, expectedRows, len(rows))
+	assert.Equal(t, []string{"", "", "summoner", "1", "summoner", "", "", "4", "2", "0.5000", "1.0000", "1750.0000"}, rows[1])
+}
@@ -0,0 +1,106 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpponentProfileSummarize(t *testing.T) {
+	team := func(summonerID int, monsterIDs ...int) BattleTeam {
+		battleTeam := BattleTeam{Summoner: CollectionCard{CardDetailID: summonerID, Level: 1}}
+		for _, id := range monsterIDs {
+			battleTeam.Monsters = append(battleTeam.Monsters, CollectionCard{CardDetailID: id, Level: 1})
+		}
+		return battleTeam
+	}
+	profile := NewOpponentProfile("player1")
+	profile.AddBattle(ProfiledBattle{CreatedDate: "2022-06-02T00:00:00.000Z", ManaCap: 20, Color: COLOR_RED, Team: team(1, 2, 3), Won: true})
+	profile.AddBattle(ProfiledBattle{CreatedDate: "2022-06-01T00:00:00.000Z", ManaCap: 20, Color: COLOR_RED, Team: team(1, 3, 2)})
+	profile.AddBattle(ProfiledBattle{CreatedDate: "2022-06-03T00:00:00.000Z", ManaCap: 30, Rulesets: []Ruleset{Ruleset(RULESET_EARTHQUAKE)}, Color: COLOR_BLUE, Team: team(4, 3)})
+
+	// battles are kept oldest first
+	assert.Equal(t, "2022-06-01T00:00:00.000Z", profile.Battles[0].CreatedDate)
+	assert.Equal(t, "2022-06-03T00:00:00.000Z", profile.Battles[2].CreatedDate)
+	added := NewOpponentProfile("player1")
+	added.AddBattles([]ProfiledBattle{profile.Battles[2], profile.Battles[0], profile.Battles[1]})
+	assert.Equal(t, profile.Battles, added.Battles)
+
+	summary := profile.Summarize(nil)
+	assert.Equal(t, 3, summary.Battles)
+	assert.InDelta(t, 1.0/3, summary.Winrate, 0.0001)
+	assert.Equal(t, 1, summary.Summoners[0].CardDetailID)
+	assert.Equal(t, 2, summary.Summoners[0].Count)
+	assert.Equal(t, 3, summary.Monsters[0].CardDetailID)
+	assert.Equal(t, 1.0, summary.Monsters[0].Share)
+	assert.Equal(t, []int{2, 1, 0, 0, 0, 0}, summary.Monsters[0].Positions)
+	assert.Equal(t, []ColorUsage{{Color: COLOR_RED, Count: 2, Share: 2.0 / 3}, {Color: COLOR_BLUE, Count: 1, Share: 1.0 / 3}}, summary.Splinters)
+
+	byManaCap := profile.SummarizeByManaCap()
+	assert.Equal(t, 2, byManaCap[20].Battles)
+	assert.Equal(t, 1, byManaCap[30].Battles)
+	byRuleset := profile.SummarizeByRuleset()
+	assert.Equal(t, 1, len(byRuleset))
+	assert.Equal(t, 4, byRuleset[Ruleset(RULESET_EARTHQUAKE)].Summoners[0].CardDetailID)
+
+	// no battles
+	assert.Equal(t, 0, profile.Summarize(func(b ProfiledBattle) bool { return false }).Battles)
+}
+
+func TestPredictTeams(t *testing.T) {
+	setUp := func() (CardDetailMap, OpponentProfile) {
+		summoner := GetDefaultFakeSummoner().GetCardDetail()
+		summoner.ID = 1
+		summoner.Color = COLOR_RED
+		monster := GetDefaultFakeMeleeOnlyCardDetail()
+		monster.ID = 2
+		monster.Color = COLOR_RED
+		otherMonster := GetDefaultFakeMeleeOnlyCardDetail()
+		otherMonster.ID = 3
+		otherMonster.Color = COLOR_RED
+		cardDetailMap := CardDetailMap{1: summoner, 2: monster, 3: otherMonster}
+
+		small := BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 1}, Monsters: []CollectionCard{{CardDetailID: 2, Level: 1}}}
+		big := BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 1}, Monsters: []CollectionCard{{CardDetailID: 2, Level: 1}, {CardDetailID: 3, Level: 1}}}
+		profile := NewOpponentProfile("player1")
+		profile.AddBattle(ProfiledBattle{CreatedDate: "2022-06-01T00:00:00.000Z", ManaCap: 10, Color: COLOR_RED, Team: small})
+		profile.AddBattle(ProfiledBattle{CreatedDate: "2022-06-02T00:00:00.000Z", ManaCap: 10, Color: COLOR_RED, Team: small})
+		profile.AddBattle(ProfiledBattle{CreatedDate: "2022-06-03T00:00:00.000Z", ManaCap: 30, Color: COLOR_RED, Team: big})
+		return cardDetailMap, profile
+	}
+
+	// teams over the mana cap are left out
+	cardDetailMap, profile := setUp()
+	predictions := profile.PredictTeams(cardDetailMap, NewTeamRules(10, []Ruleset{}))
+	assert.Equal(t, 1, len(predictions))
+	assert.Equal(t, 2, predictions[0].Battles)
+	assert.InDelta(t, 1, predictions[0].Probability, 0.0001)
+
+	// closer mana caps are more likely
+	predictions = profile.PredictTeams(cardDetailMap, NewTeamRules(30, []Ruleset{}))
+	assert.Equal(t, 2, len(predictions))
+	assert.Equal(t, 2, len(predictions[0].Team.Monsters))
+	assert.InDelta(t, 1, predictions[0].Probability+predictions[1].Probability, 0.0001)
+
+	// inactive splinters
+	rules := NewTeamRules(0, []Ruleset{})
+	rules.Settings.InactiveColors = []CardColor{COLOR_RED}
+	assert.Empty(t, profile.PredictTeams(cardDetailMap, rules))
+
+	// teams over the level caps of the match are predicted capped: the monster costs 1 more mana at level 8
+	cardDetailMap, _ = setUp()
+	pricey := cardDetailMap[2]
+	pricey.Stats.Mana = []any{5, 5, 5, 5, 5, 5, 5, 6}
+	cardDetailMap[2] = pricey
+	veteran := BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 10}, Monsters: []CollectionCard{{CardDetailID: 2, Level: 8}}}
+	profile = NewOpponentProfile("player1")
+	profile.AddBattle(ProfiledBattle{CreatedDate: "2022-06-01T00:00:00.000Z", ManaCap: 10, Color: COLOR_RED, Team: veteran})
+	rules = NewTeamRules(10, []Ruleset{})
+	assert.Empty(t, profile.PredictTeams(cardDetailMap, rules))
+	rules.Settings.RatingLevel = RATING_LEVEL_BRONZE
+	predictions = profile.PredictTeams(cardDetailMap, rules)
+	assert.Equal(t, 1, len(predictions))
+	assert.Equal(t, 3, predictions[0].Team.Summoner.Level)
+	assert.Equal(t, 3, predictions[0].Team.Monsters[0].Level)
+}
@@ -0,0 +1,164 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	simulator "github.com/YukiUmetsu/go-spl-simulator"
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+Red summoner 1 and blue summoner 4, red monsters 2 and 3, blue monster 5 and gray monster 6.
+Every card costs 5 mana. The pool has the summoners at level 1 and the monsters at level 8.
+*/
+func createFakeOptimizerCatalog() (CardDetailMap, simulator.CardPool) {
+	summoner := func(id int, color CardColor) CardDetail {
+		detail := GetDefaultFakeSummoner().GetCardDetail()
+		detail.ID = id
+		detail.Color = color
+		return detail
+	}
+	monster := func(id int, color CardColor, detail CardDetail) CardDetail {
+		detail.ID = id
+		detail.Color = color
+		return detail
+	}
+	cardDetailMap := CardDetailMap{
+		1: summoner(1, COLOR_RED),
+		4: summoner(4, COLOR_BLUE),
+		2: monster(2, COLOR_RED, GetDefaultFakeMeleeOnlyCardDetail()),
+		3: monster(3, COLOR_RED, GetDefaultFakeMagicOnlyCardDetail()),
+		5: monster(5, COLOR_BLUE, GetDefaultFakeMeleeOnlyCardDetail()),
+		6: monster(6, COLOR_GRAY, GetDefaultFakeRangeOnlyCardDetail()),
+	}
+	pool := simulator.CardPool{}
+	for id, detail := range cardDetailMap {
+		level := 8
+		if IsSummonerCard(detail) {
+			level = 1
+		}
+		pool[id] = CollectionCard{CardDetailID: id, Level: level}
+	}
+	return cardDetailMap, pool
+}
+
+func TestOptimizeTeam(t *testing.T) {
+	cardDetailMap, pool := createFakeOptimizerCatalog()
+	rules := NewTeamRules(15, []Ruleset{Ruleset(RULESET_STANDARD)})
+	redTeam := BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 1}, Monsters: []CollectionCard{{CardDetailID: 2, Level: 1}}}
+	blueTeam := BattleTeam{Summoner: CollectionCard{CardDetailID: 4, Level: 1}, Monsters: []CollectionCard{{CardDetailID: 5, Level: 1}, {CardDetailID: 6, Level: 1}}}
+	opponents := []simulator.WeightedOpponent{
+		{Name: "red", Team: redTeam, Weight: 3},
+		{Name: "blue", Team: blueTeam, Weight: 1},
+		{Name: "never", Team: redTeam, Weight: 0},
+	}
+	options := simulator.OptimizerOptions{Rules: rules, Candidates: 20, Finalists: 3, ScreeningIterations: 4, Iterations: 10, Workers: 4, Seed: 1}
+
+	lineups, err := simulator.OptimizeTeam(cardDetailMap, pool, "player1", opponents, options)
+	assert.Nil(t, err)
+	// only the finalists are returned
+	assert.Equal(t, 3, len(lineups))
+	for i, lineup := range lineups {
+		// legal lineups
+		assert.Empty(t, rules.ValidateBattleTeam(cardDetailMap, lineup.Team))
+		assert.Equal(t, "player1", lineup.Team.Player)
+		// monsters are capped by the level 1 common summoner
+		for _, m := range lineup.Team.Monsters {
+			assert.Equal(t, 1, m.Level)
+		}
+
+		// expected win rate weighted by opponent, the worst case ignores opponents without weight
+		assert.Equal(t, len(opponents), len(lineup.Winrates))
+		assert.Equal(t, options.Iterations, lineup.Winrates[0].Games)
+		expected := (3*lineup.Winrates[0].Winrate + lineup.Winrates[1].Winrate) / 4
+		assert.InDelta(t, expected, lineup.ExpectedWinrate, 1e-9)
+		worstCase := lineup.Winrates[0].Winrate
+		worstCaseOpponent := "red"
+		if lineup.Winrates[1].Winrate < worstCase {
+			worstCase = lineup.Winrates[1].Winrate
+			worstCaseOpponent = "blue"
+		}
+		assert.Equal(t, worstCase, lineup.WorstCaseWinrate)
+		assert.Equal(t, worstCaseOpponent, lineup.WorstCaseOpponent)
+
+		// best first
+		if i > 0 {
+			assert.GreaterOrEqual(t, lineups[i-1].ExpectedWinrate, lineup.ExpectedWinrate)
+		}
+	}
+
+	// a single opponent
+	lineups, err = simulator.OptimizeTeamAgainst(cardDetailMap, pool, "player1", redTeam, options)
+	assert.Nil(t, err)
+	assert.Equal(t, GetLineupKey(redTeam), lineups[0].WorstCaseOpponent)
+
+	// invalid opponents or pool
+	_, err = simulator.OptimizeTeam(cardDetailMap, pool, "player1", []simulator.WeightedOpponent{{Team: redTeam, Weight: -1}}, options)
+	assert.NotNil(t, err)
+	_, err = simulator.OptimizeTeam(cardDetailMap, pool, "player1", []simulator.WeightedOpponent{{Team: redTeam, Weight: 0}}, options)
+	assert.NotNil(t, err)
+	_, err = simulator.OptimizeTeam(cardDetailMap, simulator.CardPool{}, "player1", opponents, options)
+	assert.NotNil(t, err)
+}
+
+func TestOptimizeTeamWithMatchSettings(t *testing.T) {
+	cardDetailMap, pool := createFakeOptimizerCatalog()
+	rules := NewTeamRules(0, []Ruleset{Ruleset(RULESET_STANDARD)})
+	rules.Settings.InactiveColors = []CardColor{COLOR_RED}
+	opponent := BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 1}, Monsters: []CollectionCard{{CardDetailID: 2, Level: 1}}}
+	options := simulator.OptimizerOptions{Rules: rules, Candidates: 10, Finalists: 2, ScreeningIterations: 2, Iterations: 2, Workers: 2, Seed: 2}
+
+	lineups, err := simulator.OptimizeTeamAgainst(cardDetailMap, pool, "player1", opponent, options)
+	assert.Nil(t, err)
+	for _, lineup := range lineups {
+		// only the blue summoner with blue and gray monsters is left
+		assert.Equal(t, 4, lineup.Team.Summoner.CardDetailID)
+		for _, m := range lineup.Team.Monsters {
+			assert.Contains(t, []int{5, 6}, m.CardDetailID)
+		}
+	}
+}
+
+func TestGetWeightedOpponents(t *testing.T) {
+	team := BattleTeam{Summoner: CollectionCard{CardDetailID: 1}, Monsters: []CollectionCard{{CardDetailID: 2}}}
+	other := BattleTeam{Summoner: CollectionCard{CardDetailID: 1}, Monsters: []CollectionCard{{CardDetailID: 3}}}
+	opponents := simulator.GetWeightedOpponents([]BattleTeam{team, other, team})
+	assert.Equal(t, []simulator.WeightedOpponent{{Name: "1: 2", Team: team, Weight: 2}, {Name: "1: 3", Team: other, Weight: 1}}, opponents)
+}
+
+func TestRunRoundRobin(t *testing.T) {
+	cardDetailMap, _ := createFakeOptimizerCatalog()
+	rules := NewTeamRules(15, []Ruleset{Ruleset(RULESET_STANDARD)})
+	team := func(summonerID int, monsterIDs ...int) BattleTeam {
+		battleTeam := BattleTeam{Summoner: CollectionCard{CardDetailID: summonerID, Level: 1}}
+		for _, id := range monsterIDs {
+			battleTeam.Monsters = append(battleTeam.Monsters, CollectionCard{CardDetailID: id, Level: 1})
+		}
+		return battleTeam
+	}
+	teams := []simulator.TeamSpec{{Name: "red", Team: team(1, 2, 3)}, {Name: "blue", Team: team(4, 5, 6)}, {Name: "gray", Team: team(1, 6)}}
+	options := simulator.RoundRobinOptions{Rules: rules, Iterations: 6, Workers: 3}
+
+	result, err := simulator.RunRoundRobin(cardDetailMap, teams, options)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(result.Rankings))
+	assert.Equal(t, 6, result.Matrix.Games[0][1])
+	assert.Equal(t, 6, result.Winrates[1][2].Games)
+
+	// the same legality rules as the optimizer
+	for _, illegal := range []BattleTeam{
+		team(1, 2, 5),    // blue monster with a red summoner
+		team(1, 2, 2),    // the same card twice
+		team(1, 2, 3, 6), // over the mana cap
+		team(1),          // no monster
+		team(2, 3),       // a monster as summoner
+	} {
+		_, err := simulator.RunRoundRobin(cardDetailMap, append(teams, simulator.TeamSpec{Name: "illegal", Team: illegal}), options)
+		assert.NotNil(t, err)
+	}
+	inactive := options
+	inactive.Rules.Settings.InactiveColors = []CardColor{COLOR_BLUE}
+	_, err = simulator.RunRoundRobin(cardDetailMap, teams, inactive)
+	assert.NotNil(t, err)
+}
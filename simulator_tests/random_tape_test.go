@@ -0,0 +1,192 @@
+package simulator_tests
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomDrawString(t *testing.T) {
+	draw := RandomDraw{Round: 2, Label: RANDOM_EVENT_DODGE, Actor: "Chicken", Target: "Sea Monster", Probability: 0.25, Roll: 0.37}
+	assert.Equal(t, "round 2, dodge Chicken vs Sea Monster: 0.37 < 0.25 → false", draw.String())
+	assert.Equal(t, 0.75, draw.GetOutcomeProbability())
+
+	draw = RandomDraw{Round: 3, Label: RANDOM_EVENT_SCATTERSHOT, Actor: "Chicken", Options: 3, Picked: 1, Overridden: true}
+	assert.Equal(t, "round 3, scattershot target Chicken: 2 of 3 (forced)", draw.String())
+	assert.Equal(t, 1.0/3, draw.GetOutcomeProbability())
+}
+
+func TestRandomTape(t *testing.T) {
+	tape := NewRandomTape()
+	m1 := GetDefaultFakeMonster(ATTACK_TYPE_MELEE)
+	m2 := GetDefaultFakeMonster(ATTACK_TYPE_MAGIC)
+	m1.SetCardDetail(createNamedCardDetail(m1.GetCardDetail(), "Chicken"))
+	m2.SetCardDetail(createNamedCardDetail(m2.GetCardDetail(), "Sea Monster"))
+	tape.DescribeDraw(1, m1, m2)
+	success := tape.Chance(RANDOM_EVENT_DODGE, 0.3)
+	picked := tape.Pick(RANDOM_EVENT_SCATTERSHOT, 3)
+	assert.Equal(t, 2, len(tape.Draws))
+	assert.Equal(t, success, tape.Draws[0].Success)
+	assert.Equal(t, tape.Draws[0].Roll < 0.3, success)
+	assert.Equal(t, m1.GetName(), tape.Draws[0].Actor)
+	assert.Equal(t, m2.GetName(), tape.Draws[0].Target)
+	// the description is only of the next draw
+	assert.Equal(t, "", tape.Draws[1].Actor)
+	assert.Equal(t, picked, tape.Draws[1].Picked)
+
+	// replayed with the recorded rolls and a new probability
+	replay := NewReplayTape(tape.Draws)
+	replay.DescribeDraw(1, m1, m2)
+	assert.Equal(t, tape.Draws[0].Roll < 0.9, replay.Chance(RANDOM_EVENT_DODGE, 0.9))
+	assert.Equal(t, tape.Draws[0].Roll, replay.Draws[0].Roll)
+	assert.Equal(t, picked, replay.Pick(RANDOM_EVENT_SCATTERSHOT, 3))
+	assert.Equal(t, 0, replay.GetDivergedDraws())
+
+	// overridden
+	replay = NewReplayTape(tape.Draws, ForceSuccess(0, !success), ForcePick(1, (picked+1)%3))
+	replay.DescribeDraw(1, m1, m2)
+	assert.Equal(t, !success, replay.Chance(RANDOM_EVENT_DODGE, 0.3))
+	assert.True(t, replay.Draws[0].Overridden)
+	assert.Equal(t, (picked+1)%3, replay.Pick(RANDOM_EVENT_SCATTERSHOT, 3))
+
+	// an override of the other kind keeps the outcome
+	replay = NewReplayTape(tape.Draws, ForcePick(0, 1))
+	replay.DescribeDraw(1, m1, m2)
+	assert.Equal(t, success, replay.Chance(RANDOM_EVENT_DODGE, 0.3))
+	assert.False(t, replay.Draws[0].Overridden)
+
+	// draws of other monsters or labels aren't replayed by the recorded draws
+	replay = NewReplayTape(tape.Draws)
+	replay.DescribeDraw(1, m2, m1)
+	replay.Chance(RANDOM_EVENT_DODGE, 0.5)
+	replay.Chance(RANDOM_EVENT_STUN, 0.5)
+	assert.Equal(t, 2, replay.GetDivergedDraws())
+
+	// draws without a recorded draw are the same in every replay of the tape
+	again := NewReplayTape(tape.Draws)
+	again.DescribeDraw(1, m2, m1)
+	again.Chance(RANDOM_EVENT_DODGE, 0.5)
+	again.Chance(RANDOM_EVENT_STUN, 0.5)
+	assert.Equal(t, replay.Draws, again.Draws)
+}
+
+func createNamedCardDetail(detail CardDetail, name string, abilities ...Ability) CardDetail {
+	detail.Name = name
+	if len(abilities) > 0 {
+		levelAbilities := make([]any, 0)
+		for _, ability := range abilities {
+			levelAbilities = append(levelAbilities, string(ability))
+		}
+		detail.Stats.Abilities = []any{levelAbilities}
+	}
+	return detail
+}
+
+func TestReplayGame(t *testing.T) {
+	createGame := createFakeGameFactory(
+		[]CardDetail{GetDefaultFakeMeleeOnlyCardDetail(), GetDefaultFakeMagicOnlyCardDetail()},
+		[]CardDetail{GetDefaultFakeMeleeOnlyCardDetail(), GetDefaultFakeMagicOnlyCardDetail()},
+	)
+	game := createGame()
+	tape := RecordGame(game)
+	assert.Greater(t, len(tape.Draws), 0)
+	assert.Contains(t, tape.Draws[0].String(), RANDOM_EVENT_TIE_BREAK)
+
+	replayed, replayTape := ReplayGame(createGame, tape.Draws)
+	assert.Equal(t, game.GetWinner(), replayed.GetWinner())
+	assert.Equal(t, tape.Draws, replayTape.Draws)
+	assert.Equal(t, 0, replayTape.GetDivergedDraws())
+}
+
+func TestFindPivotalDraws(t *testing.T) {
+	createGame := createFakeGameFactory(
+		[]CardDetail{GetDefaultFakeMeleeOnlyCardDetail(), GetDefaultFakeMagicOnlyCardDetail()},
+		[]CardDetail{GetDefaultFakeMeleeOnlyCardDetail(), GetDefaultFakeMagicOnlyCardDetail()},
+	)
+	game := createGame()
+	tape := RecordGame(game)
+
+	pivotalDraws := FindPivotalDraws(createGame, tape.Draws)
+	for _, pivotal := range pivotalDraws {
+		assert.NotEqual(t, game.GetWinner(), pivotal.Winner)
+		replayed, _ := ReplayGame(createGame, tape.Draws, pivotal.Override)
+		assert.Equal(t, pivotal.Winner, replayed.GetWinner())
+		// tie breaks are even
+		assert.False(t, pivotal.IsBadLuckFor(pivotal.Winner))
+		assert.Equal(t, pivotal.Draw.GetOutcomeProbability(), pivotal.GetOverrideProbability())
+	}
+}
+
+func TestReplayGameWithOverride(t *testing.T) {
+	// the stunned brute skips its attacks, so each stun decides when the game ends
+	toughen := func(detail CardDetail) CardDetail {
+		detail.Stats.Health = []any{20, 20, 20, 20, 20, 20, 20, 20}
+		return detail
+	}
+	createGame := createFakeGameFactory(
+		[]CardDetail{toughen(createNamedCardDetail(GetDefaultFakeMeleeOnlyCardDetail(), "Stunner", Ability(ABILITY_STUN)))},
+		[]CardDetail{toughen(createNamedCardDetail(GetDefaultFakeMeleeOnlyCardDetail(), "Brute"))},
+	)
+	recorded := RecordGame(createGame()).Draws
+	stunIndex := -1
+	for i, draw := range recorded {
+		if draw.Label == RANDOM_EVENT_STUN {
+			stunIndex = i
+			break
+		}
+	}
+	assert.NotEqual(t, -1, stunIndex)
+	stun := recorded[stunIndex]
+	assert.Equal(t, "Stunner", stun.Actor)
+
+	// the override changes whether the target plays its next turn, and so the draws after it
+	replayed, tape := ReplayGame(createGame, recorded, ForceSuccess(stunIndex, !stun.Success))
+	assert.Equal(t, recorded[:stunIndex], tape.Draws[:stunIndex])
+	assert.Equal(t, !stun.Success, tape.Draws[stunIndex].Success)
+	assert.True(t, tape.Draws[stunIndex].Overridden)
+
+	// the other draws are replayed by the recorded draws of the same round, label and monsters
+	replayedCounts := make(map[string]int)
+	for _, draw := range tape.Draws[stunIndex+1:] {
+		key := fmt.Sprintf("%d %s %s %s", draw.Round, draw.Label, draw.Actor, draw.Target)
+		sameDraws := make([]RandomDraw, 0)
+		for _, r := range recorded {
+			if fmt.Sprintf("%d %s %s %s", r.Round, r.Label, r.Actor, r.Target) == key {
+				sameDraws = append(sameDraws, r)
+			}
+		}
+		if key == fmt.Sprintf("%d %s %s %s", stun.Round, stun.Label, stun.Actor, stun.Target) {
+			// the overridden draw is the first recorded draw of its key
+			sameDraws = sameDraws[1:]
+		}
+		if replayedCounts[key] < len(sameDraws) && !draw.IsPick() {
+			assert.Equal(t, sameDraws[replayedCounts[key]].Roll, draw.Roll, draw.String())
+		}
+		replayedCounts[key]++
+	}
+
+	// replays of the same draws and overrides play the same game
+	again, againTape := ReplayGame(createGame, recorded, ForceSuccess(stunIndex, !stun.Success))
+	assert.Equal(t, tape.Draws, againTape.Draws)
+	assert.Equal(t, replayed.GetWinner(), again.GetWinner())
+}
+
+func TestPivotalDrawIsBadLuckFor(t *testing.T) {
+	dodge := RandomDraw{Label: RANDOM_EVENT_DODGE, Probability: 0.1, Roll: 0.05, Success: true}
+	pivotal := PivotalDraw{Draw: dodge, Override: ForceSuccess(0, false), Winner: TEAM_NUM_ONE}
+	assert.Equal(t, 0.9, pivotal.GetOverrideProbability())
+	assert.True(t, pivotal.IsBadLuckFor(TEAM_NUM_ONE))
+	assert.False(t, pivotal.IsBadLuckFor(TEAM_NUM_TWO))
+
+	// the likely outcome was drawn
+	dodge = RandomDraw{Label: RANDOM_EVENT_DODGE, Probability: 0.1, Roll: 0.5, Success: false}
+	pivotal = PivotalDraw{Draw: dodge, Override: ForceSuccess(0, true), Winner: TEAM_NUM_ONE}
+	assert.False(t, pivotal.IsBadLuckFor(TEAM_NUM_ONE))
+
+	// every pick is as likely
+	scattershot := RandomDraw{Label: RANDOM_EVENT_SCATTERSHOT, Options: 3, Picked: 0}
+	pivotal = PivotalDraw{Draw: scattershot, Override: ForcePick(0, 2), Winner: TEAM_NUM_ONE}
+	assert.False(t, pivotal.IsBadLuckFor(TEAM_NUM_ONE))
+}
@@ -0,0 +1,196 @@
+package simulator_tests
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+/* Fury that gave +2 attack */
+type fakeOldFuryHandler struct {
+	testFuryHandler
+}
+
+func (fakeOldFuryHandler) ModifyStat(owner *MonsterCard, stat Stat, value int) int {
+	if stat == STAT_ATTACK {
+		return value + 2
+	}
+	return value
+}
+
+/* Thorns that did 1 damage */
+type fakeOldThornsHandler struct {
+	ThornsHandler
+}
+
+func (fakeOldThornsHandler) OnBeingHit(g *Game, owner *MonsterCard, hit *AbilityHitContext) BattleDamage {
+	return HitMonsterWithPhysical(g, hit.Attacker, 1)
+}
+
+/* Historical version with every behavior switched on, the built in versions only have the current rules */
+func createFakeHistoricalRulesVersion() RulesVersion {
+	return RulesVersion{
+		Name:           "test-historical",
+		EffectiveUntil: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC),
+		Config:         DefaultEngineConfig(),
+		Behavior: RulesBehavior{
+			TauntIgnoredByTargetAbilities: true,
+			DivineShieldStopsBlast:        true,
+			PiercingMeleeOnly:             true,
+		},
+	}
+}
+
+func TestGetRulesVersionAt(t *testing.T) {
+	t.Cleanup(ResetRulesVersions)
+	current, err := GetRulesVersion(RULES_VERSION_CURRENT)
+	assert.Nil(t, err)
+	assert.True(t, current.IsLive())
+	assert.Equal(t, 1, len(GetRulesVersions()))
+
+	_, err = GetRulesVersion("unknown")
+	assert.NotNil(t, err)
+
+	// the historical version is used before its end, the current one after
+	historical := createFakeHistoricalRulesVersion()
+	RegisterRulesVersion(historical)
+	assert.False(t, historical.IsLive())
+	assert.Equal(t, historical.Name, GetRulesVersionAt(historical.EffectiveUntil.Add(-time.Hour)).Name)
+	assert.Equal(t, RULES_VERSION_CURRENT, GetRulesVersionAt(historical.EffectiveUntil).Name)
+
+	changeDate := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	oldConfig := DefaultEngineConfig()
+	oldConfig.FatigueRoundNumber = 30
+	RegisterRulesVersion(RulesVersion{Name: "test-before-2022-03", EffectiveUntil: changeDate, Config: oldConfig})
+
+	// registered versions come before the versions live after them
+	assert.Equal(t, "test-before-2022-03", GetRulesVersionAt(changeDate.Add(-time.Hour)).Name)
+	assert.Equal(t, historical.Name, GetRulesVersionAt(changeDate).Name)
+
+	// from the date of a historic battle
+	version, err := GetRulesVersionOfBattle(BattleHistory{CreatedDate: "2022-02-22T10:09:40.213Z"})
+	assert.Nil(t, err)
+	assert.Equal(t, 30, version.Config.FatigueRoundNumber)
+	version, err = GetRulesVersionOfBattle(BattleHistory{CreatedDate: "2022-04-22T10:09:40.213Z"})
+	assert.Nil(t, err)
+	assert.True(t, version.Behavior.PiercingMeleeOnly)
+
+	_, err = GetRulesVersionOfBattle(BattleHistory{CreatedDate: "yesterday"})
+	assert.NotNil(t, err)
+}
+
+func TestUnregisterRulesVersion(t *testing.T) {
+	t.Cleanup(ResetRulesVersions)
+	RegisterRulesVersion(RulesVersion{Name: "test-removed", EffectiveUntil: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)})
+	assert.Nil(t, UnregisterRulesVersion("test-removed"))
+	_, err := GetRulesVersion("test-removed")
+	assert.NotNil(t, err)
+
+	assert.NotNil(t, UnregisterRulesVersion("test-removed"))
+	assert.NotNil(t, UnregisterRulesVersion(RULES_VERSION_CURRENT))
+
+	// only the built in versions are left after a reset
+	RegisterRulesVersion(createFakeHistoricalRulesVersion())
+	ResetRulesVersions()
+	_, err = GetRulesVersion(createFakeHistoricalRulesVersion().Name)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, len(GetRulesVersions()))
+}
+
+func TestRulesBehavior(t *testing.T) {
+	var game *Game
+	var t1 *GameTeam
+	var t2 *GameTeam
+	setUp := func(version RulesVersion) {
+		game = &Game{}
+		t1, t2 = CreateFakeGameTeam(), CreateFakeGameTeam()
+		game.CreateWithRulesVersion(t1, t2, []Ruleset{RULESET_EQUAL_OPPORTUNITY}, true, version)
+	}
+
+	for _, c := range []struct {
+		version  RulesVersion
+		behavior RulesBehavior
+	}{
+		{NewCurrentRulesVersion(), RulesBehavior{}},
+		{createFakeHistoricalRulesVersion(), RulesBehavior{TauntIgnoredByTargetAbilities: true, DivineShieldStopsBlast: true, PiercingMeleeOnly: true}},
+	} {
+		setUp(c.version)
+		assert.Equal(t, c.behavior, game.GetRulesBehavior(), c.version.Name)
+
+		// sneak goes for the taunt monster unless taunt is ignored, other attackers always do
+		setUp(c.version)
+		sneaker := t1.GetMonstersList()[2]
+		sneaker.AddAbility(ABILITY_SNEAK)
+		t2.GetMonstersList()[1].AddAbility(ABILITY_TAUNT)
+		expectedTarget := t2.GetMonstersList()[1]
+		if c.behavior.TauntIgnoredByTargetAbilities {
+			expectedTarget = t2.GetMonstersList()[2]
+		}
+		assert.Equal(t, expectedTarget, game.GetTargetForRangedAttack(sneaker), c.version.Name)
+		assert.Equal(t, t2.GetMonstersList()[1], game.GetTargetForMagicAttack(t1.GetMonstersList()[1]), c.version.Name)
+
+		// ranged attacks pierce unless piercing is melee only
+		setUp(c.version)
+		attacker := t1.GetMonstersList()[2]
+		attacker.AddSummonerRanged(2)
+		attacker.AddAbility(ABILITY_PIERCING)
+		game.AttackMonsterPhase(attacker, t2.GetMonstersList()[0], ATTACK_TYPE_RANGED)
+		calculations, _ := game.GetDamageCalculations()
+		expectedPiercing := 2
+		if c.behavior.PiercingMeleeOnly {
+			expectedPiercing = 0
+		}
+		assert.Equal(t, expectedPiercing, calculations[0].PiercingDamage, c.version.Name)
+
+		// an attack absorbed by divine shield blasts the neighbors unless divine shield stops it
+		setUp(c.version)
+		attacker = t1.GetMonstersList()[0]
+		attacker.AddAbility(ABILITY_BLAST)
+		t2.GetMonstersList()[0].AddAbility(ABILITY_DIVINE_SHIELD)
+		game.AttackMonsterPhase(attacker, t2.GetMonstersList()[0], ATTACK_TYPE_MELEE)
+		assert.Equal(t, c.behavior.DivineShieldStopsBlast, t2.GetMonstersList()[1].GetArmor() == TEST_DEFAULT_ARMOR, c.version.Name)
+	}
+}
+
+func TestCreateWithRulesVersion(t *testing.T) {
+	version := NewCurrentRulesVersion()
+	version.Name = "test-old-thorns"
+	version.AbilityHandlers = []AbilityHandler{fakeOldThornsHandler{}}
+
+	var game Game
+	t1, t2 := CreateFakeGameTeam(), CreateFakeGameTeam()
+	game.CreateWithRulesVersion(t1, t2, []Ruleset{RULESET_STANDARD}, false, version)
+	attacker := t1.GetFirstAliveMonster()
+	target := t2.GetFirstAliveMonster()
+	target.AddAbility(ABILITY_THORNS)
+
+	// thorns of the version
+	game.MaybeApplyThorns(attacker, target, ATTACK_TYPE_MELEE)
+	assert.Equal(t, TEST_DEFAULT_ARMOR-1, attacker.GetArmor())
+
+	// registered thorns in other games
+	game2, t3, t4 := CreateFakeGameAndTeams()
+	attacker = t3.GetFirstAliveMonster()
+	target = t4.GetFirstAliveMonster()
+	target.AddAbility(ABILITY_THORNS)
+	game2.MaybeApplyThorns(attacker, target, ATTACK_TYPE_MELEE)
+	assert.Equal(t, TEST_DEFAULT_ARMOR-2, attacker.GetArmor())
+
+	// stat modifiers of the version, also after the cards are reset
+	version.AbilityHandlers = append(version.AbilityHandlers, fakeOldFuryHandler{testFuryHandler{calls: make(map[string]int)}})
+	game.CreateWithRulesVersion(t1, t2, []Ruleset{RULESET_STANDARD}, false, version)
+	t1.GetFirstAliveMonster().AddAbility(TEST_ABILITY_FURY)
+	assert.Equal(t, TEST_DEFAULT_ATTACK+2, t1.GetFirstAliveMonster().GetPostAbilityMelee())
+	game.Reset()
+	t1.GetFirstAliveMonster().AddAbility(TEST_ABILITY_FURY)
+	assert.Equal(t, TEST_DEFAULT_ATTACK+2, t1.GetFirstAliveMonster().GetPostAbilityMelee())
+
+	// the registered modifier is replaced, not added to
+	t.Cleanup(ResetAbilityHandlers)
+	RegisterAbilityHandler(testFuryHandler{calls: make(map[string]int)})
+	assert.Equal(t, TEST_DEFAULT_ATTACK+2, t1.GetFirstAliveMonster().GetPostAbilityMelee())
+	t3.GetFirstAliveMonster().AddAbility(TEST_ABILITY_FURY)
+	assert.Equal(t, TEST_DEFAULT_ATTACK+1, t3.GetFirstAliveMonster().GetPostAbilityMelee())
+}
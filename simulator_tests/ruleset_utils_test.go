@@ -158,3 +158,125 @@ func TestDoRulesetPreGameBuff(t *testing.T) {
 		log.Fatalln("poison was not given in noxious fumes ruleset")
 	}
 }
+
+func TestValidateTeamForRulesets(t *testing.T) {
+	// no errors when the rulesets don't restrict the team
+	team := CreateFakeGameTeam()
+	assert.Empty(t, ValidateTeamForRulesets([]Ruleset{RULESET_ARMORED_UP, RULESET_STANDARD}, team))
+
+	// legendary monsters can't be played in lost legendaries
+	team = CreateFakeGameTeam()
+	m := team.GetMonstersList()[0]
+	cardDetail := m.GetCardDetail()
+	cardDetail.Rarity = 4
+	m.SetCardDetail(cardDetail)
+	errs := ValidateTeamForRulesets([]Ruleset{RULESET_LOST_LEGENDARIES}, team)
+	assert.Equal(t, 1, len(errs))
+	restrictionErr, ok := errs[0].(*TeamRestrictionError)
+	assert.True(t, ok)
+	assert.Equal(t, Ruleset(RULESET_LOST_LEGENDARIES), restrictionErr.Ruleset)
+
+	// monsters with a ranged attack can't be played in broken arrows
+	team = CreateFakeGameTeam()
+	assert.Equal(t, 1, len(ValidateTeamForRulesets([]Ruleset{RULESET_BROKEN_ARROWS}, team)))
+}
+
+type fakeHouseRulesetHandler struct {
+	BaseRulesetHandler
+}
+
+func (fakeHouseRulesetHandler) OnPreGame(team1, team2 *GameTeam) {
+	ApplyToBothTeamMonsters(team1, team2, func(m *MonsterCard) { m.Speed = 1 })
+}
+
+func (fakeHouseRulesetHandler) ModifyDodgeChance(attacker, target *MonsterCard, attackType CardAttackType, dodgeChance float64) float64 {
+	return 1
+}
+
+func TestRegisterRulesetHandler(t *testing.T) {
+	houseRuleset := Ruleset("Test House Rule")
+	assert.False(t, HasRulesetHandler(houseRuleset))
+
+	RegisterRulesetHandler(fakeHouseRulesetHandler{BaseRulesetHandler{Ruleset: houseRuleset}})
+	assert.True(t, HasRulesetHandler(houseRuleset))
+
+	// pre game hook is applied to both teams
+	t1, t2 := CreateFakeGameTeam(), CreateFakeGameTeam()
+	DoRulesetPreGameBuff([]Ruleset{houseRuleset}, t1, t2)
+	assert.Equal(t, 1, t1.GetMonstersList()[0].Speed)
+	assert.Equal(t, 1, t2.GetMonstersList()[0].Speed)
+
+	// dodge hook is applied
+	attacker := GetDefaultFakeMonster(ATTACK_TYPE_MELEE)
+	target := GetDefaultFakeMonster(ATTACK_TYPE_MELEE)
+	assert.Equal(t, float64(1), GetDodgeChance([]Ruleset{houseRuleset}, attacker, target, ATTACK_TYPE_MELEE))
+}
+
+func TestAimTrueRulesetHandler(t *testing.T) {
+	attacker := GetDefaultFakeMonster(ATTACK_TYPE_MELEE)
+	target := GetDefaultFakeMonsterWithAbility(ATTACK_TYPE_MELEE, []Ability{ABILITY_DODGE})
+	assert.Greater(t, GetDodgeChance([]Ruleset{RULESET_STANDARD}, attacker, target, ATTACK_TYPE_MELEE), float64(0))
+	assert.Equal(t, float64(0), GetDodgeChance([]Ruleset{RULESET_AIM_TRUE}, attacker, target, ATTACK_TYPE_MELEE))
+}
+
+func TestDoRulesetPreGameBuffNewerRulesets(t *testing.T) {
+	createTeams := func() (*GameTeam, *GameTeam) {
+		return CreateFakeGameTeam(), CreateFakeGameTeam()
+	}
+
+	// gives return fire ability to all monsters in fire & regret ruleset
+	t1, t2 := createTeams()
+	DoRulesetPreGameBuff([]Ruleset{RULESET_FIRE_AND_REGRET}, t1, t2)
+	t1Monsters := t1.GetAliveMonsters()
+	t2Monsters := t2.GetAliveMonsters()
+	assert.Equal(t, Ability(ABILITY_RETURN_FIRE), t1Monsters[0].Abilities[0])
+	assert.Equal(t, Ability(ABILITY_RETURN_FIRE), t2Monsters[0].Abilities[0])
+
+	// doesn't give return fire twice
+	t1, t2 = createTeams()
+	t1.GetAliveMonsters()[0].AddAbility(ABILITY_RETURN_FIRE)
+	DoRulesetPreGameBuff([]Ruleset{RULESET_FIRE_AND_REGRET}, t1, t2)
+	assert.Equal(t, 1, len(t1.GetAliveMonsters()[0].Abilities))
+
+	// gives magic reflect ability to all monsters in counterspell ruleset
+	t1, t2 = createTeams()
+	DoRulesetPreGameBuff([]Ruleset{RULESET_COUNTERSPELL}, t1, t2)
+	t1Monsters = t1.GetAliveMonsters()
+	t2Monsters = t2.GetAliveMonsters()
+	assert.Equal(t, Ability(ABILITY_MAGIC_REFLECT), t1Monsters[0].Abilities[0])
+	assert.Equal(t, Ability(ABILITY_MAGIC_REFLECT), t2Monsters[0].Abilities[0])
+
+	// gives enrage ability to all monsters in what doesn't kill you ruleset
+	t1, t2 = createTeams()
+	DoRulesetPreGameBuff([]Ruleset{RULESET_WHAT_DOESNT_KILL_YOU}, t1, t2)
+	t1Monsters = t1.GetAliveMonsters()
+	t2Monsters = t2.GetAliveMonsters()
+	assert.Equal(t, Ability(ABILITY_ENRAGE), t1Monsters[0].Abilities[0])
+	assert.Equal(t, Ability(ABILITY_ENRAGE), t2Monsters[0].Abilities[0])
+
+	// gives bloodlust ability to all monsters in are you not entertained ruleset
+	t1, t2 = createTeams()
+	DoRulesetPreGameBuff([]Ruleset{RULESET_ARE_YOU_NOT_ENTERTAINED}, t1, t2)
+	t1Monsters = t1.GetAliveMonsters()
+	t2Monsters = t2.GetAliveMonsters()
+	assert.Equal(t, Ability(ABILITY_BLOODLUST), t1Monsters[0].Abilities[0])
+	assert.Equal(t, Ability(ABILITY_BLOODLUST), t2Monsters[0].Abilities[0])
+}
+
+func TestValidateTeamForNewerRulesets(t *testing.T) {
+	// fake team has a melee, a magic and a ranged monster
+	team := CreateFakeGameTeam()
+
+	// only ranged monsters in going the distance
+	errs := ValidateTeamForRulesets([]Ruleset{RULESET_GOING_THE_DISTANCE}, team)
+	assert.Equal(t, 2, len(errs))
+
+	// only magic monsters in wands out
+	errs = ValidateTeamForRulesets([]Ruleset{RULESET_WANDS_OUT}, team)
+	assert.Equal(t, 2, len(errs))
+
+	// newer rulesets are supported
+	for _, r := range []Ruleset{RULESET_FIRE_AND_REGRET, RULESET_GOING_THE_DISTANCE, RULESET_WANDS_OUT, RULESET_WHAT_DOESNT_KILL_YOU, RULESET_COUNTERSPELL, RULESET_ARE_YOU_NOT_ENTERTAINED} {
+		assert.True(t, IsRulesetSupported(r))
+	}
+}
@@ -0,0 +1,90 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamEditApply(t *testing.T) {
+	setUp := func() BattleTeam {
+		return BattleTeam{
+			Summoner: CollectionCard{CardDetailID: 1, Level: 1},
+			Monsters: []CollectionCard{{CardDetailID: 2, Level: 1}, {CardDetailID: 3, Level: 1}, {CardDetailID: 4, Level: 1}},
+		}
+	}
+	ids := func(team BattleTeam) []int {
+		monsterIDs := make([]int, 0)
+		for _, m := range team.Monsters {
+			monsterIDs = append(monsterIDs, m.CardDetailID)
+		}
+		return monsterIDs
+	}
+
+	team := setUp()
+	edited, err := TeamEdit{Kind: TEAM_EDIT_SWAP, Position: 1, Card: CollectionCard{CardDetailID: 9, Level: 2}}.Apply(team)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2, 9, 4}, ids(edited))
+	// the team is not modified
+	assert.Equal(t, []int{2, 3, 4}, ids(team))
+
+	edited, _ = TeamEdit{Kind: TEAM_EDIT_ADD, Position: 0, Card: CollectionCard{CardDetailID: 9}}.Apply(team)
+	assert.Equal(t, []int{9, 2, 3, 4}, ids(edited))
+	edited, _ = TeamEdit{Kind: TEAM_EDIT_ADD, Position: 3, Card: CollectionCard{CardDetailID: 9}}.Apply(team)
+	assert.Equal(t, []int{2, 3, 4, 9}, ids(edited))
+
+	edited, _ = TeamEdit{Kind: TEAM_EDIT_REMOVE, Position: 1}.Apply(team)
+	assert.Equal(t, []int{2, 4}, ids(edited))
+
+	edited, _ = TeamEdit{Kind: TEAM_EDIT_MOVE, Position: 0, NewPosition: 2}.Apply(team)
+	assert.Equal(t, []int{3, 4, 2}, ids(edited))
+	edited, _ = TeamEdit{Kind: TEAM_EDIT_MOVE, Position: 2, NewPosition: 0}.Apply(team)
+	assert.Equal(t, []int{4, 2, 3}, ids(edited))
+
+	edited, _ = TeamEdit{Kind: TEAM_EDIT_SUMMONER, Card: CollectionCard{CardDetailID: 8, Level: 3}}.Apply(team)
+	assert.Equal(t, 8, edited.Summoner.CardDetailID)
+
+	edited, _ = TeamEdit{Kind: TEAM_EDIT_LEVEL, Position: SUMMONER_POSITION, Level: 4}.Apply(team)
+	assert.Equal(t, 4, edited.Summoner.Level)
+	edited, _ = TeamEdit{Kind: TEAM_EDIT_LEVEL, Position: 2, Level: 5}.Apply(team)
+	assert.Equal(t, 5, edited.Monsters[2].Level)
+	assert.Equal(t, 1, team.Monsters[2].Level)
+
+	// invalid edits
+	for _, e := range []TeamEdit{
+		{Kind: TEAM_EDIT_SWAP, Position: 3},
+		{Kind: TEAM_EDIT_ADD, Position: 4},
+		{Kind: TEAM_EDIT_REMOVE, Position: -1},
+		{Kind: TEAM_EDIT_MOVE, Position: 0, NewPosition: 3},
+		{Kind: TEAM_EDIT_LEVEL, Position: 0, Level: 0},
+		{Kind: "unknown"},
+	} {
+		_, err := e.Apply(team)
+		assert.NotNil(t, err, e.String())
+	}
+	_, err = TeamEdit{Kind: TEAM_EDIT_REMOVE, Position: 0}.Apply(BattleTeam{Monsters: []CollectionCard{{CardDetailID: 2}}})
+	assert.NotNil(t, err)
+}
+
+func TestApplyTeamEdits(t *testing.T) {
+	battleDetails := BattleDetails{
+		Team1: BattleTeam{Monsters: []CollectionCard{{CardDetailID: 2}}},
+		Team2: BattleTeam{Monsters: []CollectionCard{{CardDetailID: 3}}},
+	}
+	edited, err := ApplyTeamEdits(battleDetails, []TeamEdit{
+		{Team: TEAM_NUM_TWO, Kind: TEAM_EDIT_ADD, Position: 1, Card: CollectionCard{CardDetailID: 4}},
+		{Team: TEAM_NUM_TWO, Kind: TEAM_EDIT_MOVE, Position: 1, NewPosition: 0},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, battleDetails.Team1, edited.Team1)
+	assert.Equal(t, []CollectionCard{{CardDetailID: 4}, {CardDetailID: 3}}, edited.Team2.Monsters)
+
+	// the battle is returned unchanged on error
+	edited, err = ApplyTeamEdits(battleDetails, []TeamEdit{
+		{Team: TEAM_NUM_ONE, Kind: TEAM_EDIT_SWAP, Position: 0, Card: CollectionCard{CardDetailID: 9}},
+		{Team: TEAM_NUM_TIE, Kind: TEAM_EDIT_REMOVE},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, battleDetails, edited)
+}
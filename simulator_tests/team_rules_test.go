@@ -0,0 +1,88 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsColorCompatible(t *testing.T) {
+	assert.True(t, IsColorCompatible(COLOR_RED, COLOR_RED))
+	assert.True(t, IsColorCompatible(COLOR_RED, COLOR_GRAY))
+	assert.True(t, IsColorCompatible(COLOR_GOLD, COLOR_BLUE))
+	assert.False(t, IsColorCompatible(COLOR_RED, COLOR_BLUE))
+	assert.False(t, IsColorCompatible(COLOR_RED, COLOR_GOLD))
+}
+
+func TestValidateBattleTeamRules(t *testing.T) {
+	setUp := func() (CardDetailMap, BattleTeam) {
+		summoner := GetDefaultFakeSummoner().GetCardDetail()
+		summoner.ID = 1
+		summoner.Color = COLOR_RED
+		cardDetailMap := CardDetailMap{summoner.ID: summoner}
+		battleTeam := BattleTeam{Summoner: CollectionCard{CardDetailID: summoner.ID, Level: 1}}
+		for id, color := range map[int]CardColor{2: COLOR_RED, 3: COLOR_GRAY, 4: COLOR_BLUE, 5: COLOR_GREEN} {
+			monster := GetDefaultFakeMeleeOnlyCardDetail()
+			monster.ID = id
+			monster.Color = color
+			cardDetailMap[id] = monster
+		}
+		return cardDetailMap, battleTeam
+	}
+	monsters := func(ids ...int) []CollectionCard {
+		cards := make([]CollectionCard, 0)
+		for _, id := range ids {
+			cards = append(cards, CollectionCard{CardDetailID: id, Level: 1})
+		}
+		return cards
+	}
+
+	// legal
+	cardDetailMap, battleTeam := setUp()
+	battleTeam.Monsters = monsters(2, 3)
+	assert.Empty(t, NewTeamRules(15, []Ruleset{}).ValidateBattleTeam(cardDetailMap, battleTeam))
+
+	// mana cap
+	assert.Equal(t, 1, len(NewTeamRules(14, []Ruleset{}).ValidateBattleTeam(cardDetailMap, battleTeam)))
+
+	// no monster, duplicated monster, summoner as a monster
+	battleTeam.Monsters = monsters()
+	assert.Equal(t, 1, len(NewTeamRules(0, []Ruleset{}).ValidateBattleTeam(cardDetailMap, battleTeam)))
+	battleTeam.Monsters = monsters(2, 2)
+	assert.Equal(t, 1, len(NewTeamRules(0, []Ruleset{}).ValidateBattleTeam(cardDetailMap, battleTeam)))
+	battleTeam.Monsters = monsters(2, 1)
+	assert.Equal(t, 1, len(NewTeamRules(0, []Ruleset{}).ValidateBattleTeam(cardDetailMap, battleTeam)))
+
+	// other splinter
+	battleTeam.Monsters = monsters(2, 4)
+	assert.Equal(t, 1, len(NewTeamRules(0, []Ruleset{}).ValidateBattleTeam(cardDetailMap, battleTeam)))
+
+	// a dragon summoner plays one other splinter
+	summoner := cardDetailMap[1]
+	summoner.Color = COLOR_GOLD
+	cardDetailMap[1] = summoner
+	battleTeam.Monsters = monsters(3, 4)
+	assert.Empty(t, NewTeamRules(0, []Ruleset{}).ValidateBattleTeam(cardDetailMap, battleTeam))
+	battleTeam.Monsters = monsters(3, 4, 5)
+	assert.Equal(t, 1, len(NewTeamRules(0, []Ruleset{}).ValidateBattleTeam(cardDetailMap, battleTeam)))
+
+	// cards that can't be set up
+	cardDetailMap, battleTeam = setUp()
+	battleTeam.Monsters = []CollectionCard{{CardDetailID: 2, Level: 20}}
+	errs := NewTeamRules(0, []Ruleset{}).ValidateBattleTeam(cardDetailMap, battleTeam)
+	assert.Equal(t, 1, len(errs))
+	_, ok := errs[0].(*CardDecodeError)
+	assert.True(t, ok)
+
+	// rulesets
+	cardDetailMap, battleTeam = setUp()
+	battleTeam.Monsters = monsters(2)
+	errs = NewTeamRules(0, []Ruleset{Ruleset(RULESET_LITTLE_LEAGUE)}).ValidateBattleTeam(cardDetailMap, battleTeam)
+	assert.Equal(t, 2, len(errs))
+}
+
+func TestGetLineupKey(t *testing.T) {
+	team := BattleTeam{Summoner: CollectionCard{CardDetailID: 167}, Monsters: []CollectionCard{{CardDetailID: 131}, {CardDetailID: 91}}}
+	assert.Equal(t, "167: 131, 91", GetLineupKey(team))
+}
@@ -181,3 +181,14 @@ func CreateFakeGameAndTeams() (*Game, *GameTeam, *GameTeam) {
 	(&game).Create(t1, t2, rulesets, false)
 	return &game, t1, t2
 }
+
+// CreateFakeLoggingGameAndTeams - same as CreateFakeGameAndTeams but the game keeps battle logs
+func CreateFakeLoggingGameAndTeams() (*Game, *GameTeam, *GameTeam) {
+	var game Game
+	t1 := CreateFakeGameTeam()
+	t2 := CreateFakeGameTeam()
+	rulesets := make([]Ruleset, 0)
+	rulesets = append(rulesets, RULESET_EQUAL_OPPORTUNITY)
+	(&game).Create(t1, t2, rulesets, true)
+	return &game, t1, t2
+}
@@ -0,0 +1,76 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	simulator "github.com/YukiUmetsu/go-spl-simulator"
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeUpgrades(t *testing.T) {
+	// the grower hits first and as hard as its level. it dies to the 4th hit of a wall,
+	// so it beats a wall of 10 health from level 3, 16 health from level 4 and 30 health from level 8.
+	summoner := GetDefaultFakeSummoner().GetCardDetail()
+	summoner.ID = 1
+	summoner.Name = "Legendary Summoner"
+	summoner.Rarity = 4
+	grower := createFakeMeleeCardDetail("Grower", 10, 0, 6, 0)
+	grower.ID = 2
+	grower.Rarity = 2
+	grower.Stats.Attack = []any{1, 2, 3, 4, 5, 6, 7, 8}
+	wall := func(id, health int) CardDetail {
+		detail := createFakeMeleeCardDetail("Wall", health, 0, 5, 3)
+		detail.ID = id
+		detail.Rarity = 2
+		return detail
+	}
+	cardDetailMap := CardDetailMap{1: summoner, 2: grower, 3: wall(3, 10), 4: wall(4, 16), 5: wall(5, 30)}
+
+	opponent := func(wallID int) BattleTeam {
+		return BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 4}, Monsters: []CollectionCard{{CardDetailID: wallID, Level: 1}}}
+	}
+	opponents := []BattleTeam{opponent(3), opponent(4), opponent(5)}
+	// a level 2 legendary summoner caps rares at level 4
+	team := BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 2}, Monsters: []CollectionCard{{CardDetailID: 2, Level: 2}}}
+
+	report, err := simulator.AnalyzeUpgrades(cardDetailMap, team, opponents, []Ruleset{}, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, report.Baseline.Wins)
+	assert.Equal(t, 6, report.Baseline.Games)
+
+	type upgrade struct {
+		position      int
+		cardName      string
+		upgradedLevel int
+		isMaxLevel    bool
+		wins          int
+	}
+	upgrades := make([]upgrade, 0)
+	for _, result := range report.Results {
+		upgrades = append(upgrades, upgrade{result.Position, result.CardName, result.UpgradedLevel, result.IsMaxLevel, result.Winrate.Wins})
+	}
+	assert.Equal(t, []upgrade{
+		// the max level grower is played at level 4 and still loses to the 30 health wall
+		{0, "Grower", 8, true, 4},
+		{0, "Grower", 3, false, 2},
+		// upgrading the summoner doesn't raise the level of the grower above its own
+		{SUMMONER_POSITION, "Legendary Summoner", 3, false, 0},
+		{SUMMONER_POSITION, "Legendary Summoner", 4, true, 0},
+	}, upgrades)
+	assert.Greater(t, report.Results[0].Delta, report.Results[1].Delta)
+	assert.Greater(t, report.Results[1].Delta, report.Results[2].Delta)
+
+	// upgrading the summoner raises the cap of a monster played below its level
+	team = BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 1}, Monsters: []CollectionCard{{CardDetailID: 2, Level: 8}}}
+	report, err = simulator.AnalyzeUpgrades(cardDetailMap, team, opponents, []Ruleset{}, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, report.Baseline.Wins)
+	assert.Equal(t, 2, len(report.Results))
+	assert.Equal(t, SUMMONER_POSITION, report.Results[0].Position)
+	assert.Equal(t, 4, report.Results[0].UpgradedLevel)
+	assert.Equal(t, 6, report.Results[0].Winrate.Wins)
+	assert.Equal(t, SUMMONER_POSITION, report.Results[1].Position)
+	assert.Equal(t, 2, report.Results[1].UpgradedLevel)
+	assert.Equal(t, 4, report.Results[1].Winrate.Wins)
+}
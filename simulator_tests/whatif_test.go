@@ -0,0 +1,45 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	simulator "github.com/YukiUmetsu/go-spl-simulator"
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+/* A legendary summoner (1) and a common melee monster (2) */
+func createFakeLevelCapCatalog() CardDetailMap {
+	summoner := GetDefaultFakeSummoner().GetCardDetail()
+	summoner.ID = 1
+	summoner.Rarity = 4
+	monster := GetDefaultFakeMeleeOnlyCardDetail()
+	monster.ID = 2
+	monster.Rarity = 1
+	return CardDetailMap{1: summoner, 2: monster}
+}
+
+func TestAnalyzeWhatIfLevelCaps(t *testing.T) {
+	cardDetailMap := createFakeLevelCapCatalog()
+	team := BattleTeam{Summoner: CollectionCard{CardDetailID: 1, Level: 4}, Monsters: []CollectionCard{{CardDetailID: 2, Level: 8}}}
+	battleDetails := BattleDetails{Team1: team, Team2: team}
+	rules := NewTeamRules(99, []Ruleset{Ruleset(RULESET_STANDARD)})
+	rules.Settings.RatingLevel = RATING_LEVEL_SILVER
+
+	result, err := simulator.AnalyzeWhatIf(cardDetailMap, battleDetails, rules, TEAM_NUM_ONE, []TeamEdit{
+		{Team: TEAM_NUM_ONE, Kind: TEAM_EDIT_LEVEL, Position: SUMMONER_POSITION, Level: 1},
+	}, 10)
+	assert.Nil(t, err)
+
+	// silver caps legendaries at 2 and commons at 5
+	assert.Equal(t, []LevelCapAdjustment{
+		{Position: SUMMONER_POSITION, CardDetailID: 1, Level: 4, CappedLevel: 2},
+		{Position: 0, CardDetailID: 2, Level: 8, CappedLevel: 5},
+	}, result.OriginalLevelCaps.Team1)
+	// a level 1 legendary summoner caps commons at 3
+	assert.Equal(t, []LevelCapAdjustment{
+		{Position: 0, CardDetailID: 2, Level: 8, CappedLevel: 3},
+	}, result.EditedLevelCaps.Team1)
+	assert.Equal(t, result.OriginalLevelCaps.Team2, result.EditedLevelCaps.Team2)
+	assert.Equal(t, 10, result.Winrate.Games)
+}
@@ -0,0 +1,60 @@
+package simulator_tests
+
+import (
+	"testing"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddResult(t *testing.T) {
+	matrix := NewWinrateMatrix([]string{"a", "b"})
+	matrix.AddResult(0, 1, 6, 2, 10)
+	assert.Equal(t, 0.6, matrix.GetWinrate(0, 1))
+	assert.Equal(t, 0.2, matrix.GetWinrate(1, 0))
+	assert.Equal(t, 0.2, matrix.GetTieRate(0, 1))
+	assert.Equal(t, 0.2, matrix.GetTieRate(1, 0))
+	assert.Equal(t, 10, matrix.Games[1][0])
+	assert.Equal(t, float64(0), matrix.GetWinrate(0, 0))
+}
+
+func TestGetAverageWinrate(t *testing.T) {
+	matrix := NewWinrateMatrix([]string{"a", "b", "c"})
+	matrix.AddResult(0, 1, 8, 0, 10)
+	matrix.AddResult(0, 2, 4, 0, 10)
+	assert.InDelta(t, 0.6, matrix.GetAverageWinrate(0), 0.0001)
+	assert.InDelta(t, 0.2, matrix.GetAverageWinrate(1), 0.0001)
+}
+
+func TestGetRankings(t *testing.T) {
+	matrix := NewWinrateMatrix([]string{"weak", "strong", "even"})
+	matrix.AddResult(0, 1, 2, 0, 10)
+	matrix.AddResult(0, 2, 4, 2, 10)
+	matrix.AddResult(1, 2, 7, 0, 10)
+
+	rankings := matrix.GetRankings()
+	assert.Equal(t, []string{"strong", "even", "weak"}, []string{rankings[0].Name, rankings[1].Name, rankings[2].Name})
+	assert.Equal(t, 1, rankings[0].Index)
+	assert.Greater(t, rankings[0].Elo, float64(ELO_INITIAL_RATING))
+	assert.Less(t, rankings[2].Elo, float64(ELO_INITIAL_RATING))
+
+	// ratings average the initial rating
+	sum := 0.0
+	for _, r := range rankings {
+		sum += r.Elo
+	}
+	assert.InDelta(t, ELO_INITIAL_RATING, sum/3, 0.0001)
+
+	// even matchups keep the initial rating
+	even := NewWinrateMatrix([]string{"a", "b"})
+	even.AddResult(0, 1, 4, 2, 10)
+	assert.InDelta(t, ELO_INITIAL_RATING, even.GetEloRatings()[0], 0.0001)
+}
+
+func TestGetManaCost(t *testing.T) {
+	summoner := GetDefaultFakeSummoner()
+	monsters := []*MonsterCard{GetDefaultFakeMonster(ATTACK_TYPE_MELEE), GetDefaultFakeMonster(ATTACK_TYPE_RANGED)}
+	var team GameTeam
+	team.Create(summoner, monsters, "player1")
+	assert.Equal(t, TEST_DEFAULT_MANA*3, team.GetManaCost())
+}
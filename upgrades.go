@@ -0,0 +1,131 @@
+package simulator
+
+import (
+	"sort"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+)
+
+/* Win rate of the team when one card is played at a higher level */
+type UpgradeResult struct {
+	Position      int // position of the monster, SUMMONER_POSITION for the summoner
+	CardName      string
+	CardDetailID  int
+	CurrentLevel  int
+	UpgradedLevel int
+	IsMaxLevel    bool
+	Winrate       WinrateEstimate
+	// Winrate - baseline win rate and its 95% confidence bounds
+	Delta      float64
+	DeltaLower float64
+	DeltaUpper float64
+}
+
+type UpgradeReport struct {
+	Baseline WinrateEstimate
+	Results  []UpgradeResult // sorted by win rate gain, biggest first
+}
+
+/*
+Simulates the team against every opponent with each card at its current level, level + 1 and max level
+and ranks the upgrades by win rate gain. The monster levels are capped by the summoner level in every game,
+so upgrading the summoner can raise the level its monsters are played at, and a monster upgraded above its cap gains nothing.
+Returns a *CardDecodeError if a card of the team or the opponents can't be set up.
+*/
+func AnalyzeUpgrades(cardDetailMap CardDetailMap, team BattleTeam, opponents []BattleTeam, rulesets []Ruleset, iterationsPerOpponent int) (UpgradeReport, error) {
+	createCappedGame := func(battleDetails BattleDetails) (Game, error) {
+		game, _, _, err := CreateCappedGame(cardDetailMap, battleDetails, rulesets, false)
+		return game, err
+	}
+	baseline, err := estimateWinrateAgainstOpponents(createCappedGame, team, opponents, iterationsPerOpponent)
+	if err != nil {
+		return UpgradeReport{}, err
+	}
+	report := UpgradeReport{Baseline: baseline}
+
+	addResults := func(position int, card CollectionCard, upgrade func(upgradedTeam *BattleTeam, level int)) error {
+		cardDetail := cardDetailMap[card.CardDetailID]
+		maxLevel := GetMaxLevelOfCard(cardDetail)
+		for _, level := range getUpgradedLevels(card.Level, maxLevel) {
+			upgradedTeam := team
+			upgradedTeam.Monsters = make([]CollectionCard, len(team.Monsters))
+			copy(upgradedTeam.Monsters, team.Monsters)
+			upgrade(&upgradedTeam, level)
+
+			winrate, err := estimateWinrateAgainstOpponents(createCappedGame, upgradedTeam, opponents, iterationsPerOpponent)
+			if err != nil {
+				return err
+			}
+			result := UpgradeResult{
+				Position:      position,
+				CardName:      cardDetail.Name,
+				CardDetailID:  cardDetail.ID,
+				CurrentLevel:  card.Level,
+				UpgradedLevel: level,
+				IsMaxLevel:    level == maxLevel,
+				Winrate:       winrate,
+			}
+			result.Delta, result.DeltaLower, result.DeltaUpper = winrate.GetDeltaBounds(report.Baseline)
+			report.Results = append(report.Results, result)
+		}
+		return nil
+	}
+
+	err = addResults(SUMMONER_POSITION, team.Summoner, func(upgradedTeam *BattleTeam, level int) {
+		upgradedTeam.Summoner.Level = level
+	})
+	if err != nil {
+		return UpgradeReport{}, err
+	}
+	for position, monster := range team.Monsters {
+		position := position
+		err := addResults(position, monster, func(upgradedTeam *BattleTeam, level int) {
+			upgradedTeam.Monsters[position].Level = level
+		})
+		if err != nil {
+			return UpgradeReport{}, err
+		}
+	}
+
+	sort.SliceStable(report.Results, func(i, j int) bool {
+		return report.Results[i].Delta > report.Results[j].Delta
+	})
+	return report, nil
+}
+
+/* level + 1 and the max level, if the card isn't there yet */
+func getUpgradedLevels(level, maxLevel int) []int {
+	upgradedLevels := make([]int, 0)
+	if level < maxLevel {
+		upgradedLevels = append(upgradedLevels, level+1)
+	}
+	if level+1 < maxLevel {
+		upgradedLevels = append(upgradedLevels, maxLevel)
+	}
+	return upgradedLevels
+}
+
+/* Win rate of the team (played as team 1) over iterationsPerOpponent games against each opponent. Returns a *CardDecodeError if a card can't be set up */
+func EstimateWinrateAgainstOpponents(cardDetailMap CardDetailMap, team BattleTeam, opponents []BattleTeam, rulesets []Ruleset, iterationsPerOpponent int) (WinrateEstimate, error) {
+	return estimateWinrateAgainstOpponents(func(battleDetails BattleDetails) (Game, error) {
+		return CreateGame(cardDetailMap, battleDetails, rulesets, false)
+	}, team, opponents, iterationsPerOpponent)
+}
+
+func estimateWinrateAgainstOpponents(createGame func(battleDetails BattleDetails) (Game, error), team BattleTeam, opponents []BattleTeam, iterationsPerOpponent int) (WinrateEstimate, error) {
+	wins := 0
+	games := 0
+	for _, opponent := range opponents {
+		battleDetails := BattleDetails{Team1: team, Team2: opponent}
+		createBattleGame, err := newGameFactory(func() (Game, error) {
+			return createGame(battleDetails)
+		})
+		if err != nil {
+			return WinrateEstimate{}, err
+		}
+		estimate := EstimateWinrate(createBattleGame, TEAM_NUM_ONE, iterationsPerOpponent)
+		wins += estimate.Wins
+		games += estimate.Games
+	}
+	return NewWinrateEstimate(wins, games), nil
+}
@@ -0,0 +1,80 @@
+package simulator
+
+import (
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+)
+
+/* Win rate of a team with edited teams against the original battle */
+type WhatIfResult struct {
+	Edits    []TeamEdit
+	Original BattleDetails
+	Edited   BattleDetails
+	// team whose win rate is estimated
+	Team     TeamNumber
+	Baseline WinrateEstimate
+	Winrate  WinrateEstimate
+	// Winrate - Baseline and its 95% confidence bounds
+	Delta      float64
+	DeltaLower float64
+	DeltaUpper float64
+	// rules of the battle the edited teams break (e.g. over the mana cap), they are simulated anyway
+	Violations []error
+	// cards played at a lower level because of the summoner and match level caps
+	OriginalLevelCaps BattleLevelCaps
+	EditedLevelCaps   BattleLevelCaps
+}
+
+/*
+Applies the edits to the battle and estimates the win rate of the team with the original and the edited teams.
+Both battles are played with the levels capped by the summoner levels and the match settings of the rules.
+The edited teams are checked against the rules and any violation is reported in the result.
+*/
+func AnalyzeWhatIf(cardDetailMap CardDetailMap, battleDetails BattleDetails, rules TeamRules, team TeamNumber, edits []TeamEdit, iterations int) (WhatIfResult, error) {
+	edited, err := ApplyTeamEdits(battleDetails, edits)
+	if err != nil {
+		return WhatIfResult{}, err
+	}
+
+	estimate := func(details BattleDetails) (WinrateEstimate, BattleLevelCaps, error) {
+		capped, caps := ApplyBattleLevelCaps(cardDetailMap, details, rules.Settings)
+		createGame, err := createBattleGame(cardDetailMap, capped, rules.Rulesets)
+		if err != nil {
+			return WinrateEstimate{}, caps, err
+		}
+		return EstimateWinrate(createGame, team, iterations), caps, nil
+	}
+	result := WhatIfResult{
+		Edits:      edits,
+		Original:   battleDetails,
+		Edited:     edited,
+		Team:       team,
+		Violations: make([]error, 0),
+	}
+	result.Baseline, result.OriginalLevelCaps, err = estimate(battleDetails)
+	if err != nil {
+		return WhatIfResult{}, err
+	}
+	result.Winrate, result.EditedLevelCaps, err = estimate(edited)
+	if err != nil {
+		return WhatIfResult{}, err
+	}
+	result.Delta, result.DeltaLower, result.DeltaUpper = result.Winrate.GetDeltaBounds(result.Baseline)
+	result.Violations = append(result.Violations, rules.ValidateBattleTeam(cardDetailMap, edited.Team1)...)
+	result.Violations = append(result.Violations, rules.ValidateBattleTeam(cardDetailMap, edited.Team2)...)
+	return result, nil
+}
+
+/* Same as AnalyzeWhatIf with a saved battle */
+func AnalyzeSavedBattleWhatIf(cardDetailMap CardDetailMap, historicBattle BattleHistory, team TeamNumber, edits []TeamEdit, iterations int) (WhatIfResult, error) {
+	rules, err := ParseTeamRules(historicBattle)
+	if err != nil {
+		return WhatIfResult{}, err
+	}
+	return AnalyzeWhatIf(cardDetailMap, ParseBattleDetails(historicBattle), rules, team, edits, iterations)
+}
+
+/* Same as AnalyzeWhatIf with the battle of the id */
+func AnalyzeBattleWhatIf(battleId string, team TeamNumber, edits []TeamEdit, iterations int) (WhatIfResult, error) {
+	cardDetailMap, _ := GetAllCardDetail()
+	return AnalyzeSavedBattleWhatIf(cardDetailMap, GetHistoricBattle(battleId), team, edits, iterations)
+}
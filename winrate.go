@@ -0,0 +1,64 @@
+package simulator
+
+import (
+	"math"
+
+	. "github.com/YukiUmetsu/go-spl-simulator/game_models"
+)
+
+/* z score of a 95% confidence interval */
+const CONFIDENCE_Z_95 = 1.96
+
+/* Win rate of a team over simulated games with its 95% confidence bounds (Wilson score interval). Ties count as losses. */
+type WinrateEstimate struct {
+	Wins    int
+	Games   int
+	Winrate float64
+	Lower   float64
+	Upper   float64
+}
+
+func NewWinrateEstimate(wins, games int) WinrateEstimate {
+	estimate := WinrateEstimate{Wins: wins, Games: games}
+	if games <= 0 {
+		return estimate
+	}
+	n := float64(games)
+	p := float64(wins) / n
+	z := CONFIDENCE_Z_95
+	denominator := 1 + z*z/n
+	center := (p + z*z/(2*n)) / denominator
+	margin := z * math.Sqrt(p*(1-p)/n+z*z/(4*n*n)) / denominator
+
+	estimate.Winrate = p
+	estimate.Lower = math.Max(0, center-margin)
+	estimate.Upper = math.Min(1, center+margin)
+	return estimate
+}
+
+/* Returns the 95% confidence bounds of (e - baseline), using the normal approximation of the difference of two win rates */
+func (e WinrateEstimate) GetDeltaBounds(baseline WinrateEstimate) (float64, float64, float64) {
+	delta := e.Winrate - baseline.Winrate
+	if e.Games <= 0 || baseline.Games <= 0 {
+		return delta, delta, delta
+	}
+	variance := e.Winrate*(1-e.Winrate)/float64(e.Games) + baseline.Winrate*(1-baseline.Winrate)/float64(baseline.Games)
+	margin := CONFIDENCE_Z_95 * math.Sqrt(variance)
+	return delta, delta - margin, delta + margin
+}
+
+/*
+Plays games created by createGame iterations times and returns the win rate of the team.
+Ties count as losses, the same as in the optimizer, so a matchup that always ends in a tie has a win rate of 0 for both teams.
+*/
+func EstimateWinrate(createGame func() *Game, team TeamNumber, iterations int) WinrateEstimate {
+	wins := 0
+	for i := 0; i < iterations; i++ {
+		game := createGame()
+		game.PlayGame()
+		if game.GetWinner() == team {
+			wins += 1
+		}
+	}
+	return NewWinrateEstimate(wins, iterations)
+}